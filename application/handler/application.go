@@ -5,12 +5,14 @@ package handler
 
 import (
 	"errors"
-	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"gofr.dev/pkg/gofr"
-	"gofr.dev/pkg/gofr/cmd/terminal"
+
+	svc "zop.dev/cli/zop/application/service"
+	"zop.dev/cli/zop/utils"
 )
 
 // Errors returned by the handler package.
@@ -60,41 +62,46 @@ func (h *Handler) Add(ctx *gofr.Context) (any, error) {
 	return "Application " + name + " added successfully!", nil
 }
 
-// List retrieves and displays all applications along with their environments.
+// List retrieves and displays all applications along with their environments, as a table or in the format
+// selected with -output. With -watch the list is fetched and redrawn every -interval.
 //
 // Parameters:
 //   - ctx: The application context containing dependencies and utilities.
 //
 // Returns:
 //
-//	A newline-separated string and an error, if any.
+//	The rendered applications and an error, if any.
 func (h *Handler) List(ctx *gofr.Context) (any, error) {
-	apps, err := h.appAdd.List(ctx)
+	out, err := utils.GetOutput(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx.Out.Println("Applications and their environments:\n")
-
-	s := strings.Builder{}
-
-	for i, app := range apps {
-		ctx.Out.Printf("%d.", i+1)
-		ctx.Out.SetColor(terminal.Cyan)
-		ctx.Out.Printf(" %s \n\t", app.Name)
-		ctx.Out.ResetColor()
-
-		sort.Slice(app.Envs, func(i, j int) bool { return app.Envs[i].Level < app.Envs[j].Level })
+	return utils.Watch(ctx, func() (any, error) {
+		apps, err := h.appAdd.List(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-		for _, env := range app.Envs {
-			s.WriteString(fmt.Sprintf("%s > ", env.Name))
+		for _, app := range apps {
+			sort.Slice(app.Envs, func(i, j int) bool { return app.Envs[i].Level < app.Envs[j].Level })
 		}
 
-		ctx.Out.SetColor(terminal.Green)
-		ctx.Out.Println(s.String()[:s.Len()-2])
-		ctx.Out.ResetColor()
-		s.Reset()
-	}
+		if apps, err = utils.Select(out, apps); err != nil {
+			return "", err
+		}
 
-	return "\n", nil
+		return utils.Render(out, apps, []utils.Column[svc.Application]{
+			{Header: "ID", Value: func(app svc.Application) string { return strconv.FormatInt(app.ID, 10) }, Wide: true},
+			{Header: "Name", Value: func(app svc.Application) string { return app.Name }},
+			{Header: "Environments", Value: func(app svc.Application) string {
+				names := make([]string, 0, len(app.Envs))
+				for _, env := range app.Envs {
+					names = append(names, env.Name)
+				}
+
+				return strings.Join(names, " > ")
+			}},
+		})
+	})
 }
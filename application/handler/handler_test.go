@@ -10,7 +10,6 @@ import (
 	"gofr.dev/pkg/gofr/cmd"
 	"gofr.dev/pkg/gofr/cmd/terminal"
 	"gofr.dev/pkg/gofr/container"
-	"gofr.dev/pkg/gofr/testutil"
 
 	svc "zop.dev/cli/zop/application/service"
 )
@@ -74,57 +73,76 @@ func TestHandler_Add(t *testing.T) {
 }
 
 func TestHandler_List(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockSvc := NewMockApplicationService(ctrl)
 
+	apps := []svc.Application{
+		{ID: 1, Name: "app1",
+			Envs: []svc.Environment{{Name: "env2", Level: 2}, {Name: "env1", Level: 1}}},
+		{ID: 2, Name: "app2",
+			Envs: []svc.Environment{{Name: "dev", Level: 1}, {Name: "prod", Level: 2}}},
+	}
+
 	testCases := []struct {
 		name      string
+		args      []string
 		mockCalls []*gomock.Call
-		expected  string
+		expected  any
 		expErr    error
 	}{
 		{
-			name: "success",
+			name: "table",
+			args: []string{""},
 			mockCalls: []*gomock.Call{
-				mockSvc.EXPECT().GetApplications(gomock.Any()).
-					Return([]svc.Application{
-						{ID: 1, Name: "app1",
-							Envs: []svc.Environment{{Name: "env1", Level: 1}, {Name: "env2", Level: 2}}},
-						{ID: 2, Name: "app2",
-							Envs: []svc.Environment{{Name: "dev", Level: 1}, {Name: "prod", Level: 2}}},
-					}, nil),
+				mockSvc.EXPECT().GetApplications(gomock.Any()).Return(apps, nil),
+			},
+			expected: "NAME  ENVIRONMENTS\n" +
+				"app1  env1 > env2\n" +
+				"app2  dev > prod\n",
+		},
+		{
+			name: "csv",
+			args: []string{"", "-output=csv"},
+			mockCalls: []*gomock.Call{
+				mockSvc.EXPECT().GetApplications(gomock.Any()).Return(apps[1:], nil),
 			},
-			expected: "Applications and their environments:\n\n1.\x1b[38;5;6m app1 " +
-				"\n\t\x1b[0m\x1b[38;5;2menv1 > env2 \n\x1b[0m2.\x1b[38;5;6m app2 " +
-				"\n\t\x1b[0m\x1b[38;5;2mdev > prod \n\x1b[0m",
+			expected: "name,environments\napp2,dev > prod\n",
+		},
+		{
+			name: "json",
+			args: []string{"", "-output=json"},
+			mockCalls: []*gomock.Call{
+				mockSvc.EXPECT().GetApplications(gomock.Any()).Return(nil, nil),
+			},
+			expected: "[]\n",
 		},
 		{
 			name: "failure",
+			args: []string{""},
 			mockCalls: []*gomock.Call{
 				mockSvc.EXPECT().GetApplications(gomock.Any()).
 					Return(nil, errAPICall),
 			},
-			expErr:   errAPICall,
-			expected: "",
+			expErr: errAPICall,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			h := New(mockSvc)
-			out := testutil.StdoutOutputForFunc(func() {
-				ctx := &gofr.Context{
-					Request: cmd.NewRequest([]string{""}),
-					Out:     terminal.New(),
-				}
-
-				_, err := h.List(ctx)
+			ctx := &gofr.Context{
+				Request: cmd.NewRequest(tc.args),
+				Out:     terminal.New(),
+			}
 
-				require.Equal(t, tc.expErr, err)
-			})
+			out, err := h.List(ctx)
 
+			require.Equal(t, tc.expErr, err)
 			require.Equal(t, tc.expected, out)
 		})
 	}
@@ -4,61 +4,198 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/utils"
 )
 
 const (
-	successMessage = "Successfully Imported!"
-	maxNameLength  = 20
+	successMessage     = "Successfully Imported!"
+	dryRunMessage      = "Dry run complete, nothing was imported."
+	noAccountsImported = "No accounts found to import\n"
+	noAccountsFound    = "No accounts found\n"
+	hoursPerDay        = 24
+	tablePadding       = 2
+)
+
+var (
+	// ErrImportFailed is returned when the import of any cloud account failed, after the results were printed.
+	ErrImportFailed = errors.New("the import of some cloud accounts failed")
+
+	// ErrInvalidAccountID is returned when the -id flag is not a positive number.
+	ErrInvalidAccountID = errors.New("invalid cloud account id")
+
+	// ErrInvalidPurge is returned when the -purge flag is not a boolean.
+	ErrInvalidPurge = errors.New("invalid value for -purge, expected true or false")
+
+	// ErrInvalidKeyAge is returned when the -older-than flag is not a positive number of days.
+	ErrInvalidKeyAge = errors.New("invalid key age, -older-than takes a number of days")
 )
 
 type Handler struct {
 	accountService AccountImporter
 	accountGetter  AccountGetter
+	keyRotator     KeyRotator
+	accountRemover AccountRemover
+	describer      CredentialDescriber
+	verifier       AccountVerifier
 }
 
-func New(accountService AccountImporter, accountGetter AccountGetter) *Handler {
+func New(accountService AccountImporter, accountGetter AccountGetter, keyRotator KeyRotator,
+	accountRemover AccountRemover, describer CredentialDescriber, verifier AccountVerifier) *Handler {
 	return &Handler{
 		accountService: accountService,
 		accountGetter:  accountGetter,
+		keyRotator:     keyRotator,
+		accountRemover: accountRemover,
+		describer:      describer,
+		verifier:       verifier,
 	}
 }
 
 // Import is a handler for importing cloud accounts to zop api.
+// The accounts of every provider are imported, unless providers are selected with -provider=gcp,azure.
+// With -dry-run the providers only report what they would import, as planned or skipped accounts.
+// With -update the credentials of accounts zop api already has are replaced.
+// The result of every account is printed as a table, or in the format selected with -output. If the import
+// of any account failed, the report is printed and an error is returned.
 func (h *Handler) Import(ctx *gofr.Context) (any, error) {
-	err := h.accountService.PostAccounts(ctx)
+	out, err := utils.GetOutput(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return successMessage, nil
+	results, err := h.accountService.PostAccounts(ctx, getProviders(ctx.Param("provider")))
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := render(out, results, resultColumns(), noAccountsImported)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider.AnyFailed(results) {
+		ctx.Out.Print(report)
+
+		return nil, ErrImportFailed
+	}
+
+	if out.Format != utils.OutputTable || len(results) == 0 {
+		return report, nil
+	}
+
+	if provider.DryRun(ctx) {
+		return report + "\n" + dryRunMessage, nil
+	}
+
+	return report + "\n" + successMessage, nil
+}
+
+// getAccountID returns the cloud account id passed with -id, or zero when it is not set.
+func getAccountID(ctx *gofr.Context) (int64, error) {
+	param := ctx.Param("id")
+	if param == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidAccountID
+	}
+
+	return id, nil
 }
 
-// List is a handler for listing all cloud accounts.
+// getProviders splits the comma separated -provider flag into provider names.
+func getProviders(param string) []string {
+	providers := make([]string, 0)
+
+	for _, name := range strings.Split(param, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			providers = append(providers, name)
+		}
+	}
+
+	return providers
+}
+
+// List is a handler for listing all cloud accounts, as a table or in the format selected with -output.
+// Secrets in the provider details are redacted in every format, like in Show.
+// With -watch the list is fetched and redrawn every -interval.
 func (h *Handler) List(ctx *gofr.Context) (any, error) {
-	accounts, err := h.accountGetter.GetAccounts(ctx)
+	out, err := utils.GetOutput(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Watch(ctx, func() (any, error) {
+		accounts, err := h.accountGetter.GetAccounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return render(out, redactAccounts(accounts), accountColumns(out), noAccountsFound)
+	})
+}
+
+// RotateKeys is a handler for rotating the service account keys of the cloud accounts in zop api.
+// The keys of every account are rotated, unless an account is selected with -id.
+// With -older-than=N only keys older than N days are rotated.
+func (h *Handler) RotateKeys(ctx *gofr.Context) (any, error) {
+	var minAge time.Duration
+
+	id, err := getAccountID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(accounts) == 0 {
-		return "No accounts found", nil
+	if param := ctx.Param("older-than"); param != "" {
+		days, er := strconv.Atoi(param)
+		if er != nil || days <= 0 {
+			return nil, ErrInvalidKeyAge
+		}
+
+		minAge = time.Duration(days) * hoursPerDay * time.Hour
+	}
+
+	rotated, err := h.keyRotator.RotateKeys(ctx, id, minAge)
+	if err != nil {
+		return nil, err
 	}
 
-	header := fmt.Sprintf("%-20s %-20s %-20s %-20s %-20s\n",
-		"Name", "Provider", "ProviderID", "UpdateAt", "CreatedAt")
-	rows := ""
+	return fmt.Sprintf("Rotated %d key(s)", rotated), nil
+}
+
+// Remove is a handler for removing a cloud account from zop api. The account is selected with -id or picked
+// from a list. With -purge the service account zop created for a gcp account is deleted along with its keys
+// and IAM bindings.
+func (h *Handler) Remove(ctx *gofr.Context) (any, error) {
+	var purge bool
 
-	for _, account := range accounts {
-		if len(account.Name) > maxNameLength {
-			account.Name = account.Name[:17] + "..."
+	if param := ctx.Param("purge"); param != "" {
+		var err error
+
+		if purge, err = strconv.ParseBool(param); err != nil {
+			return nil, ErrInvalidPurge
 		}
+	}
 
-		rows += fmt.Sprintf("%-20s %-20s %-20s %-20s %-20s\n",
-			account.Name, account.Provider, account.ProviderID, account.UpdatedAt, account.CreatedAt)
+	acc, err := h.selectAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.accountRemover.RemoveAccount(ctx, acc, purge); err != nil {
+		return nil, err
 	}
 
-	return header + rows, nil
+	return fmt.Sprintf("Removed cloud account %s", acc.Name), nil
 }
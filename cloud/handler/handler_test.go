@@ -3,14 +3,21 @@ package handler
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+	"gofr.dev/pkg/gofr/cmd/terminal"
 	"gofr.dev/pkg/gofr/container"
 	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
 
+	"zop.dev/cli/zop/cloud/provider"
 	"zop.dev/cli/zop/cloud/service/list"
+	"zop.dev/cli/zop/utils"
 )
 
 var (
@@ -18,35 +25,57 @@ var (
 	errFailedToFetchAccounts = errors.New("failed to fetch accounts")
 )
 
+//nolint:gochecknoglobals //results returned by the mocked importer
+var testResults = []provider.Result{
+	{Provider: "gcp", Account: "dev@example.com", Project: "payments", Status: provider.StatusCreated},
+	{Provider: "gcp", Account: "dev@example.com", Project: "search", Status: provider.StatusSkipped, Reason: "already imported"},
+	{Provider: "aws", Account: "default", Status: provider.StatusExists},
+}
+
 func TestImport_Success(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAccountImporter := NewMockAccountImporter(ctrl)
-	mockAccountImporter.EXPECT().PostAccounts(gomock.Any()).Return(nil)
+	mockAccountImporter.EXPECT().PostAccounts(gomock.Any(), []string{}).Return(testResults, nil)
 
-	handler := New(mockAccountImporter, nil)
-	ctx := &gofr.Context{}
+	handler := New(mockAccountImporter, nil, nil, nil, nil, nil)
+	ctx := &gofr.Context{Request: cmd.NewRequest([]string{""})}
 	result, err := handler.Import(ctx)
 
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 
-	if result != successMessage {
-		t.Errorf("expected 'Successfully Imported!', got %v", result)
+	expected := "PROVIDER  ACCOUNT          PROJECT   STATUS   REASON\n" +
+		"gcp       dev@example.com  payments  created  \n" +
+		"gcp       dev@example.com  search    skipped  already imported\n" +
+		"aws       default                    exists   \n" +
+		"\n" + successMessage
+
+	if result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
 	}
 }
 
 func TestImport_Failure(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAccountImporter := NewMockAccountImporter(ctrl)
-	mockAccountImporter.EXPECT().PostAccounts(gomock.Any()).Return(errTest)
+	mockAccountImporter.EXPECT().PostAccounts(gomock.Any(), []string{}).Return(nil, errTest)
 
-	ctx := &gofr.Context{Container: &container.Container{Logger: logging.NewMockLogger(logging.INFO)}}
-	handler := New(mockAccountImporter, nil)
+	ctx := &gofr.Context{
+		Container: &container.Container{Logger: logging.NewMockLogger(logging.INFO)},
+		Request:   cmd.NewRequest([]string{""}),
+	}
+	handler := New(mockAccountImporter, nil, nil, nil, nil, nil)
 
 	result, err := handler.Import(ctx)
 
@@ -63,87 +92,594 @@ func TestImport_Failure(t *testing.T) {
 	}
 }
 
+func TestImport_ProviderFlag(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountImporter := NewMockAccountImporter(ctrl)
+	mockAccountImporter.EXPECT().PostAccounts(gomock.Any(), []string{"azure", "gcp"}).Return(nil, nil)
+
+	handler := New(mockAccountImporter, nil, nil, nil, nil, nil)
+
+	result, err := handler.Import(&gofr.Context{Request: cmd.NewRequest([]string{"", "-provider=Azure, gcp"})})
+
+	require.NoError(t, err)
+	assert.Equal(t, noAccountsImported, result)
+}
+
+func TestImport_DryRun(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	planned := []provider.Result{
+		{Provider: "aws", Account: "default", Status: provider.StatusPlanned, Reason: "access key credentials, would be posted"},
+	}
+
+	mockAccountImporter := NewMockAccountImporter(ctrl)
+	mockAccountImporter.EXPECT().PostAccounts(gomock.Any(), []string{}).Return(planned, nil).Times(2)
+
+	handler := New(mockAccountImporter, nil, nil, nil, nil, nil)
+
+	result, err := handler.Import(&gofr.Context{Request: cmd.NewRequest([]string{"", "-dry-run"})})
+
+	require.NoError(t, err)
+	assert.Equal(t, "PROVIDER  ACCOUNT  PROJECT  STATUS   REASON\n"+
+		"aws       default           planned  access key credentials, would be posted\n"+
+		"\n"+dryRunMessage, result)
+
+	result, err = handler.Import(&gofr.Context{Request: cmd.NewRequest([]string{"", "-dry-run", "-output=json"})})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"provider": "aws", "account": "default", "status": "planned",
+		"reason": "access key credentials, would be posted"}]`, result.(string))
+}
+
+func TestImport_FailedAccounts(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountImporter := NewMockAccountImporter(ctrl)
+	mockAccountImporter.EXPECT().PostAccounts(gomock.Any(), []string{}).Return([]provider.Result{
+		{Provider: "gcp", Account: "dev@example.com", Project: "payments", Status: provider.StatusFailed, Reason: "permission denied"},
+	}, nil)
+
+	handler := New(mockAccountImporter, nil, nil, nil, nil, nil)
+
+	out := testutil.StdoutOutputForFunc(func() {
+		result, err := handler.Import(&gofr.Context{Request: cmd.NewRequest([]string{""}), Out: terminal.New()})
+
+		require.ErrorIs(t, err, ErrImportFailed)
+		assert.Nil(t, result)
+	})
+
+	assert.Equal(t, "PROVIDER  ACCOUNT          PROJECT   STATUS  REASON\n"+
+		"gcp       dev@example.com  payments  failed  permission denied\n", out)
+}
+
+func TestImport_JSONOutput(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountImporter := NewMockAccountImporter(ctrl)
+	mockAccountImporter.EXPECT().PostAccounts(gomock.Any(), []string{}).Return(testResults[:2], nil)
+
+	handler := New(mockAccountImporter, nil, nil, nil, nil, nil)
+
+	result, err := handler.Import(&gofr.Context{Request: cmd.NewRequest([]string{"", "-output=json"})})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"provider": "gcp", "account": "dev@example.com", "project": "payments", "status": "created"},
+		{"provider": "gcp", "account": "dev@example.com", "project": "search", "status": "skipped", "reason": "already imported"}
+	]`, result.(string))
+
+	_, err = handler.Import(&gofr.Context{Request: cmd.NewRequest([]string{"", "-output=xml"})})
+
+	require.ErrorIs(t, err, utils.ErrUnknownOutput)
+}
+
 func TestHandler_List(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	local := time.Local
+	time.Local = time.UTC
+
+	t.Cleanup(func() { time.Local = local })
+
 	mockAccGetter := NewMockAccountGetter(ctrl)
 
+	accounts := []*list.CloudAccountResponse{
+		{ID: 1, Name: "ThisIsAVeryLongAccountName", Provider: "GCP", ProviderID: "67890",
+			UpdatedAt: utils.ParseTimestamp("2024-02-02"), CreatedAt: utils.ParseTimestamp("2023-02-02")},
+		{ID: 2, Name: "Account2", Provider: "Azure", ProviderID: "11111",
+			UpdatedAt: utils.ParseTimestamp("2024-03-03"), CreatedAt: utils.ParseTimestamp("2023-03-03")},
+	}
+
 	tests := []struct {
 		name         string
-		accounts     []*list.CloudAccountResponse
-		expectedResp string
+		args         []string
+		expectedResp any
 		expectedErr  error
 		mocks        []*gomock.Call
 	}{
 		{
 			name:         "No accounts",
-			accounts:     []*list.CloudAccountResponse{},
-			expectedResp: "No accounts found",
-			expectedErr:  nil,
+			args:         []string{""},
+			expectedResp: noAccountsFound,
 			mocks: []*gomock.Call{
 				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return([]*list.CloudAccountResponse{}, nil),
 			},
 		},
 		{
-			name: "Single account",
-			accounts: []*list.CloudAccountResponse{
-				{Name: "Account1", Provider: "AWS", ProviderID: "12345", UpdatedAt: "2024-01-01", CreatedAt: "2023-01-01"},
+			name: "table",
+			args: []string{""},
+			expectedResp: "NAME                        PROVIDER  PROVIDER ID  UPDATED AT               CREATED AT\n" +
+				"ThisIsAVeryLongAccountName  GCP       67890        2024-02-02 00:00:00 UTC  2023-02-02 00:00:00 UTC\n" +
+				"Account2                    Azure     11111        2024-03-03 00:00:00 UTC  2023-03-03 00:00:00 UTC\n",
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts, nil),
 			},
-			expectedResp: "Name                 Provider             ProviderID           UpdateAt             CreatedAt           \n" +
-				"Account1             AWS                  12345                2024-01-01           2023-01-01          \n",
-			expectedErr: nil,
+		},
+		{
+			name: "csv",
+			args: []string{"", "-output=csv"},
+			expectedResp: "name,provider,provider_id,updated_at,created_at\n" +
+				"ThisIsAVeryLongAccountName,GCP,67890,2024-02-02T00:00:00Z,2023-02-02T00:00:00Z\n" +
+				"Account2,Azure,11111,2024-03-03T00:00:00Z,2023-03-03T00:00:00Z\n",
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts, nil),
+			},
+		},
+		{
+			name: "relative time",
+			args: []string{"", "-columns=name,created_at", "-time-format=relative"},
+			expectedResp: "NAME      CREATED AT\n" +
+				"payments  2h ago\n",
 			mocks: []*gomock.Call{
 				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return([]*list.CloudAccountResponse{
-					{Name: "Account1", Provider: "AWS", ProviderID: "12345", UpdatedAt: "2024-01-01", CreatedAt: "2023-01-01"},
+					{Name: "payments", CreatedAt: utils.Timestamp{Time: time.Now().Add(-2 * time.Hour)}},
 				}, nil),
 			},
 		},
 		{
-			name: "Multiple accounts with truncation",
-			accounts: []*list.CloudAccountResponse{
-				{Name: "ThisIsAVeryLongAccountNameThatShouldBeTruncated",
-					Provider: "GCP", ProviderID: "67890", UpdatedAt: "2024-02-02", CreatedAt: "2023-02-02"},
-				{Name: "Account2", Provider: "Azure",
-					ProviderID: "11111", UpdatedAt: "2024-03-03", CreatedAt: "2023-03-03"},
+			name:        "unknown time format",
+			args:        []string{"", "-time-format=unix"},
+			expectedErr: utils.ErrUnknownTimeFormat,
+		},
+		{
+			name: "wide",
+			args: []string{"", "-wide", "-time-format=rfc3339"},
+			expectedResp: "ID  NAME      PROVIDER  PROVIDER ID  UPDATED AT            CREATED AT            PROVIDER DETAILS\n" +
+				"3   payments  gcp       payments     2024-03-03T00:00:00Z  2023-03-03T00:00:00Z  " +
+				"clientSecret=[redacted], region=us-central1\n",
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return([]*list.CloudAccountResponse{
+					{ID: 3, Name: "payments", Provider: "gcp", ProviderID: "payments",
+						UpdatedAt: utils.ParseTimestamp("2024-03-03"), CreatedAt: utils.ParseTimestamp("2023-03-03"),
+						ProviderDetails: map[string]any{"region": "us-central1", "clientSecret": "s"}},
+				}, nil),
 			},
-			expectedResp: "Name                 Provider             ProviderID           UpdateAt             CreatedAt           \n" +
-				"ThisIsAVeryLongAc... GCP                  67890                2024-02-02           2023-02-02          \n" +
-				"Account2             Azure                11111                2024-03-03           2023-03-03          \n",
-			expectedErr: nil,
+		},
+		{
+			name: "json redacts provider details",
+			args: []string{"", "-output=json"},
+			expectedResp: `[
+  {
+    "name": "payments",
+    "id": 3,
+    "provider": "gcp",
+    "providerId": "payments",
+    "providerDetails": {
+      "clientSecret": "[redacted]",
+      "region": "us-central1"
+    },
+    "createdAt": "2023-03-03T00:00:00Z",
+    "updatedAt": "2024-03-03T00:00:00Z"
+  }
+]
+`,
 			mocks: []*gomock.Call{
 				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return([]*list.CloudAccountResponse{
-					{Name: "ThisIsAVeryLongAccountNameThatShouldBeTruncated",
-						Provider: "GCP", ProviderID: "67890", UpdatedAt: "2024-02-02", CreatedAt: "2023-02-02"},
-					{Name: "Account2", Provider: "Azure", ProviderID: "11111", UpdatedAt: "2024-03-03", CreatedAt: "2023-03-03"},
+					{ID: 3, Name: "payments", Provider: "gcp", ProviderID: "payments",
+						UpdatedAt: utils.ParseTimestamp("2024-03-03"), CreatedAt: utils.ParseTimestamp("2023-03-03"),
+						ProviderDetails: map[string]any{"region": "us-central1", "clientSecret": "s"}},
 				}, nil),
 			},
 		},
 		{
-			name:         "Error from GetAccounts",
-			accounts:     nil,
+			name:         "columns",
+			args:         []string{"", "-columns=id,Provider ID,name"},
+			expectedResp: "ID  PROVIDER ID  NAME\n2   11111        Account2\n",
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts[1:], nil),
+			},
+		},
+		{
+			name:         "unknown column",
+			args:         []string{"", "-columns=region"},
 			expectedResp: "",
-			expectedErr:  errFailedToFetchAccounts,
+			expectedErr:  utils.ErrUnknownColumn,
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts, nil),
+			},
+		},
+		{
+			name: "yaml",
+			args: []string{"", "-output=yaml"},
+			expectedResp: `- name: Account2
+  id: 2
+  provider: Azure
+  providerId: "11111"
+  providerDetails: null
+  createdAt: "2023-03-03T00:00:00Z"
+  updatedAt: "2024-03-03T00:00:00Z"
+`,
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts[1:], nil),
+			},
+		},
+		{
+			name:        "unknown output",
+			args:        []string{"", "-output=xml"},
+			expectedErr: utils.ErrUnknownOutput,
+		},
+		{
+			name:        "Error from GetAccounts",
+			args:        []string{""},
+			expectedErr: errFailedToFetchAccounts,
 			mocks: []*gomock.Call{
 				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(nil, errFailedToFetchAccounts),
 			},
 		},
 	}
 
-	handler := New(nil, mockAccGetter)
+	handler := New(nil, mockAccGetter, nil, nil, nil, nil)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := &gofr.Context{}
+			ctx := &gofr.Context{Request: cmd.NewRequest(tt.args)}
 
 			resp, err := handler.List(ctx)
 
-			if tt.expectedErr != nil {
-				assert.EqualError(t, err, tt.expectedErr.Error())
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedResp, resp)
-			}
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedResp, resp)
+		})
+	}
+}
+
+func TestRotateKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeyRotator := NewMockKeyRotator(ctrl)
+
+	tests := []struct {
+		name         string
+		args         []string
+		mocks        []*gomock.Call
+		expectedResp any
+		expectedErr  error
+	}{
+		{
+			name: "all accounts",
+			args: []string{""},
+			mocks: []*gomock.Call{
+				mockKeyRotator.EXPECT().RotateKeys(gomock.Any(), int64(0), time.Duration(0)).Return(2, nil),
+			},
+			expectedResp: "Rotated 2 key(s)",
+		},
+		{
+			name: "single account older than",
+			args: []string{"", "-id=7", "-older-than=90"},
+			mocks: []*gomock.Call{
+				mockKeyRotator.EXPECT().RotateKeys(gomock.Any(), int64(7), 90*24*time.Hour).Return(1, nil),
+			},
+			expectedResp: "Rotated 1 key(s)",
+		},
+		{
+			name:        "invalid id",
+			args:        []string{"", "-id=abc"},
+			expectedErr: ErrInvalidAccountID,
+		},
+		{
+			name:        "invalid key age",
+			args:        []string{"", "-older-than=-1"},
+			expectedErr: ErrInvalidKeyAge,
+		},
+		{
+			name: "rotation error",
+			args: []string{""},
+			mocks: []*gomock.Call{
+				mockKeyRotator.EXPECT().RotateKeys(gomock.Any(), int64(0), time.Duration(0)).Return(0, errTest),
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	handler := New(nil, nil, mockKeyRotator, nil, nil, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &gofr.Context{Request: cmd.NewRequest(tt.args)}
+
+			resp, err := handler.RotateKeys(ctx)
+
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedResp, resp)
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccGetter := NewMockAccountGetter(ctrl)
+	mockRemover := NewMockAccountRemover(ctrl)
+
+	acc := &list.CloudAccountResponse{ID: 7, Name: "payments", Provider: "gcp"}
+	accounts := []*list.CloudAccountResponse{{ID: 3, Name: "search", Provider: "aws"}, acc}
+
+	tests := []struct {
+		name         string
+		args         []string
+		mocks        []*gomock.Call
+		expectedResp any
+		expectedErr  error
+	}{
+		{
+			name: "remove by id",
+			args: []string{"", "-id=7"},
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts, nil),
+				mockRemover.EXPECT().RemoveAccount(gomock.Any(), acc, false).Return(nil),
+			},
+			expectedResp: "Removed cloud account payments",
+		},
+		{
+			name: "purge",
+			args: []string{"", "-id=7", "-purge"},
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts, nil),
+				mockRemover.EXPECT().RemoveAccount(gomock.Any(), acc, true).Return(nil),
+			},
+			expectedResp: "Removed cloud account payments",
+		},
+		{
+			name: "unknown id",
+			args: []string{"", "-id=9"},
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts, nil),
+			},
+			expectedErr: ErrAccountNotFound,
+		},
+		{
+			name:        "invalid id",
+			args:        []string{"", "-id=abc"},
+			expectedErr: ErrInvalidAccountID,
+		},
+		{
+			name:        "invalid purge",
+			args:        []string{"", "-id=7", "-purge=maybe"},
+			expectedErr: ErrInvalidPurge,
+		},
+		{
+			name: "remove error",
+			args: []string{"", "-id=7"},
+			mocks: []*gomock.Call{
+				mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return(accounts, nil),
+				mockRemover.EXPECT().RemoveAccount(gomock.Any(), acc, false).Return(errTest),
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	handler := New(nil, mockAccGetter, nil, mockRemover, nil, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &gofr.Context{Request: cmd.NewRequest(tt.args)}
+
+			resp, err := handler.Remove(ctx)
+
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedResp, resp)
 		})
 	}
 }
+
+func TestShow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	local := time.Local
+	time.Local = time.UTC
+
+	t.Cleanup(func() { time.Local = local })
+
+	mockAccGetter := NewMockAccountGetter(ctrl)
+	mockDescriber := NewMockCredentialDescriber(ctrl)
+
+	acc := &list.CloudAccountResponse{ID: 7, Name: "payments", Provider: "gcp", ProviderID: "payments-prod",
+		ProviderDetails: map[string]any{"projectNumber": "1234", "region": "us-central1", "clientSecret": "s3cr3t"},
+		CreatedAt:       utils.ParseTimestamp("2024-01-02T10:00:00Z"),
+		UpdatedAt:       utils.ParseTimestamp("2024-03-04T10:00:00+02:00")}
+
+	mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return([]*list.CloudAccountResponse{acc}, nil)
+	mockDescriber.EXPECT().GetCredentialInfo(gomock.Any(), acc).
+		Return(&list.CredentialInfo{ServiceAccount: "zop-dev-1@payments-prod.iam.gserviceaccount.com", KeyID: "abc"}, nil)
+	mockAccGetter.EXPECT().GetDeploymentSpaces(gomock.Any(), int64(7)).
+		Return([]*list.DeploymentSpace{{Name: "prod-cluster", Type: "gke", Environment: "prod"}}, nil)
+
+	handler := New(nil, mockAccGetter, nil, nil, mockDescriber, nil)
+
+	resp, err := handler.Show(&gofr.Context{Request: cmd.NewRequest([]string{"", "-id=7"})})
+
+	require.NoError(t, err)
+	assert.Equal(t, `Name:               payments
+ID:                 7
+Provider:           gcp
+Provider ID:        payments-prod
+Provider details:   
+  clientSecret:     [redacted]
+  projectNumber:    1234
+  region:           us-central1
+Service account:    zop-dev-1@payments-prod.iam.gserviceaccount.com
+Key:                abc, age unavailable
+Deployment spaces:  
+  prod-cluster:     gke, environment prod
+Created:            2024-01-02 10:00:00 UTC
+Updated:            2024-03-04 08:00:00 UTC
+`, resp)
+}
+
+func TestHandler_getAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccGetter := NewMockAccountGetter(ctrl)
+	acc := &list.CloudAccountResponse{ID: 7, Name: "payments"}
+
+	mockAccGetter.EXPECT().GetAccounts(gomock.Any()).Return([]*list.CloudAccountResponse{acc}, nil).Times(2)
+
+	handler := New(nil, mockAccGetter, nil, nil, nil, nil)
+
+	got, err := handler.getAccount(&gofr.Context{}, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, acc, got)
+
+	_, err = handler.getAccount(&gofr.Context{}, 8)
+
+	require.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func Test_flattenDetails(t *testing.T) {
+	details := map[string]any{
+		"network": map[string]any{"region": "eu-west-1", "zones": []any{"a", "b"}},
+		"auth":    map[string]any{"password": "hunter2"},
+		"id":      float64(12),
+	}
+
+	assert.Equal(t, [][2]string{
+		{"auth.password", "[redacted]"},
+		{"id", "12"},
+		{"network.region", "eu-west-1"},
+		{"network.zones.0", "a"},
+		{"network.zones.1", "b"},
+	}, flattenDetails("", details, nil))
+}
+
+func TestVerify(t *testing.T) {
+	// Tables are fitted to the terminal, COLUMNS pins the width wider than every expected line.
+	t.Setenv("COLUMNS", "200")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVerifier := NewMockAccountVerifier(ctrl)
+
+	passed := []provider.Check{
+		{Provider: "gcp", Account: "payments", Check: "token", Status: provider.CheckPass},
+		{Provider: "gcp", Account: "payments", Check: "roles", Status: provider.CheckWarn, Detail: "not checked"},
+	}
+	failed := []provider.Check{
+		{Provider: "gcp", Account: "payments", Check: "key", Status: provider.CheckFail, Detail: "key disabled"},
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		mocks        []*gomock.Call
+		expectedResp any
+		expectedErr  error
+	}{
+		{
+			name: "all checks pass",
+			args: []string{""},
+			mocks: []*gomock.Call{
+				mockVerifier.EXPECT().VerifyAccounts(gomock.Any(), int64(0)).Return(passed, nil),
+			},
+			expectedResp: "PROVIDER  ACCOUNT   CHECK  STATUS  DETAIL\n" +
+				"gcp       payments  token  pass    \n" +
+				"gcp       payments  roles  warn    not checked\n",
+		},
+		{
+			name: "single account",
+			args: []string{"", "-id=7"},
+			mocks: []*gomock.Call{
+				mockVerifier.EXPECT().VerifyAccounts(gomock.Any(), int64(7)).Return(nil, nil),
+			},
+			expectedResp: noAccountsVerified,
+		},
+		{
+			name: "failed check",
+			args: []string{""},
+			mocks: []*gomock.Call{
+				mockVerifier.EXPECT().VerifyAccounts(gomock.Any(), int64(0)).Return(failed, nil),
+			},
+			expectedErr: ErrVerifyFailed,
+		},
+		{
+			name:        "invalid id",
+			args:        []string{"", "-id=abc"},
+			expectedErr: ErrInvalidAccountID,
+		},
+		{
+			name:        "unknown output",
+			args:        []string{"", "-output=xml"},
+			expectedErr: utils.ErrUnknownOutput,
+		},
+	}
+
+	handler := New(nil, nil, nil, nil, nil, mockVerifier)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &gofr.Context{Request: cmd.NewRequest(tt.args), Out: terminal.New()}
+
+			var (
+				resp any
+				err  error
+			)
+
+			testutil.StdoutOutputForFunc(func() {
+				resp, err = handler.Verify(ctx)
+			})
+
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedResp, resp)
+		})
+	}
+}
+
+func Test_redact(t *testing.T) {
+	details := map[string]any{
+		"region": "us-central1",
+		"auth":   map[string]any{"clientSecret": "s3cr3t", "tenant": "t1"},
+		"keys":   []any{map[string]any{"privateKey": "pem"}},
+	}
+
+	assert.Equal(t, map[string]any{
+		"region": "us-central1",
+		"auth":   map[string]any{"clientSecret": "[redacted]", "tenant": "t1"},
+		"keys":   []any{map[string]any{"privateKey": "[redacted]"}},
+	}, redact(details))
+	assert.Equal(t, "s3cr3t", details["auth"].(map[string]any)["clientSecret"])
+}
@@ -1,18 +1,52 @@
 package handler
 
 import (
+	"time"
+
 	"gofr.dev/pkg/gofr"
 
+	"zop.dev/cli/zop/cloud/provider"
 	"zop.dev/cli/zop/cloud/service/list"
 )
 
 // AccountImporter is an interface for importing cloud accounts to zop api.
-// It has a PostAccounts method that is used to import all local cloud accounts to the zop api to store and validate those cloud accounts.
+// It has a PostAccounts method that is used to import all local cloud accounts of the given providers,
+// or of every provider when none are given, to the zop api to store and validate those cloud accounts.
+// It returns the result of every account.
 type AccountImporter interface {
-	PostAccounts(ctx *gofr.Context) error
+	PostAccounts(ctx *gofr.Context, providers []string) ([]provider.Result, error)
 }
 
-// AccountGetter is an interface for getting cloud accounts from the zop api.
+// AccountGetter is an interface for getting cloud accounts, and the deployment spaces attached to them,
+// from the zop api.
 type AccountGetter interface {
 	GetAccounts(ctx *gofr.Context) ([]*list.CloudAccountResponse, error)
+	GetDeploymentSpaces(ctx *gofr.Context, id int64) ([]*list.DeploymentSpace, error)
+}
+
+// KeyRotator is an interface for rotating the service account keys of the cloud accounts in zop api.
+// RotateKeys rotates the keys of every cloud account, or of the account with the given id if it is not zero,
+// leaving keys younger than minAge untouched, and returns the number of rotated keys.
+type KeyRotator interface {
+	RotateKeys(ctx *gofr.Context, id int64, minAge time.Duration) (int, error)
+}
+
+// AccountRemover is an interface for removing cloud accounts from zop api.
+// RemoveAccount deletes the cloud account, with purge the credentials zop created for it in the cloud
+// are deleted as well.
+type AccountRemover interface {
+	RemoveAccount(ctx *gofr.Context, acc *list.CloudAccountResponse, purge bool) error
+}
+
+// CredentialDescriber is an interface for describing the credentials zop api holds for a cloud account.
+// GetCredentialInfo returns nil for cloud accounts it does not describe.
+type CredentialDescriber interface {
+	GetCredentialInfo(ctx *gofr.Context, acc *list.CloudAccountResponse) (*list.CredentialInfo, error)
+}
+
+// AccountVerifier is an interface for checking the health of the cloud accounts in zop api.
+// VerifyAccounts checks every cloud account, or the account with the given id if it is not zero,
+// and returns the outcome of every check.
+type AccountVerifier interface {
+	VerifyAccounts(ctx *gofr.Context, id int64) ([]provider.Check, error)
 }
@@ -11,9 +11,11 @@ package handler
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 	gofr "gofr.dev/pkg/gofr"
+	provider "zop.dev/cli/zop/cloud/provider"
 	list "zop.dev/cli/zop/cloud/service/list"
 )
 
@@ -42,17 +44,18 @@ func (m *MockAccountImporter) EXPECT() *MockAccountImporterMockRecorder {
 }
 
 // PostAccounts mocks base method.
-func (m *MockAccountImporter) PostAccounts(ctx *gofr.Context) error {
+func (m *MockAccountImporter) PostAccounts(ctx *gofr.Context, providers []string) ([]provider.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PostAccounts", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PostAccounts", ctx, providers)
+	ret0, _ := ret[0].([]provider.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // PostAccounts indicates an expected call of PostAccounts.
-func (mr *MockAccountImporterMockRecorder) PostAccounts(ctx any) *gomock.Call {
+func (mr *MockAccountImporterMockRecorder) PostAccounts(ctx, providers any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostAccounts", reflect.TypeOf((*MockAccountImporter)(nil).PostAccounts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostAccounts", reflect.TypeOf((*MockAccountImporter)(nil).PostAccounts), ctx, providers)
 }
 
 // MockAccountGetter is a mock of AccountGetter interface.
@@ -93,3 +96,173 @@ func (mr *MockAccountGetterMockRecorder) GetAccounts(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockAccountGetter)(nil).GetAccounts), ctx)
 }
+
+// GetDeploymentSpaces mocks base method.
+func (m *MockAccountGetter) GetDeploymentSpaces(ctx *gofr.Context, id int64) ([]*list.DeploymentSpace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentSpaces", ctx, id)
+	ret0, _ := ret[0].([]*list.DeploymentSpace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentSpaces indicates an expected call of GetDeploymentSpaces.
+func (mr *MockAccountGetterMockRecorder) GetDeploymentSpaces(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentSpaces", reflect.TypeOf((*MockAccountGetter)(nil).GetDeploymentSpaces), ctx, id)
+}
+
+// MockKeyRotator is a mock of KeyRotator interface.
+type MockKeyRotator struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyRotatorMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyRotatorMockRecorder is the mock recorder for MockKeyRotator.
+type MockKeyRotatorMockRecorder struct {
+	mock *MockKeyRotator
+}
+
+// NewMockKeyRotator creates a new mock instance.
+func NewMockKeyRotator(ctrl *gomock.Controller) *MockKeyRotator {
+	mock := &MockKeyRotator{ctrl: ctrl}
+	mock.recorder = &MockKeyRotatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyRotator) EXPECT() *MockKeyRotatorMockRecorder {
+	return m.recorder
+}
+
+// RotateKeys mocks base method.
+func (m *MockKeyRotator) RotateKeys(ctx *gofr.Context, id int64, minAge time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKeys", ctx, id, minAge)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKeys indicates an expected call of RotateKeys.
+func (mr *MockKeyRotatorMockRecorder) RotateKeys(ctx, id, minAge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKeys", reflect.TypeOf((*MockKeyRotator)(nil).RotateKeys), ctx, id, minAge)
+}
+
+// MockAccountRemover is a mock of AccountRemover interface.
+type MockAccountRemover struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRemoverMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountRemoverMockRecorder is the mock recorder for MockAccountRemover.
+type MockAccountRemoverMockRecorder struct {
+	mock *MockAccountRemover
+}
+
+// NewMockAccountRemover creates a new mock instance.
+func NewMockAccountRemover(ctrl *gomock.Controller) *MockAccountRemover {
+	mock := &MockAccountRemover{ctrl: ctrl}
+	mock.recorder = &MockAccountRemoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRemover) EXPECT() *MockAccountRemoverMockRecorder {
+	return m.recorder
+}
+
+// RemoveAccount mocks base method.
+func (m *MockAccountRemover) RemoveAccount(ctx *gofr.Context, acc *list.CloudAccountResponse, purge bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAccount", ctx, acc, purge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAccount indicates an expected call of RemoveAccount.
+func (mr *MockAccountRemoverMockRecorder) RemoveAccount(ctx, acc, purge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAccount", reflect.TypeOf((*MockAccountRemover)(nil).RemoveAccount), ctx, acc, purge)
+}
+
+// MockCredentialDescriber is a mock of CredentialDescriber interface.
+type MockCredentialDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialDescriberMockRecorder
+	isgomock struct{}
+}
+
+// MockCredentialDescriberMockRecorder is the mock recorder for MockCredentialDescriber.
+type MockCredentialDescriberMockRecorder struct {
+	mock *MockCredentialDescriber
+}
+
+// NewMockCredentialDescriber creates a new mock instance.
+func NewMockCredentialDescriber(ctrl *gomock.Controller) *MockCredentialDescriber {
+	mock := &MockCredentialDescriber{ctrl: ctrl}
+	mock.recorder = &MockCredentialDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialDescriber) EXPECT() *MockCredentialDescriberMockRecorder {
+	return m.recorder
+}
+
+// GetCredentialInfo mocks base method.
+func (m *MockCredentialDescriber) GetCredentialInfo(ctx *gofr.Context, acc *list.CloudAccountResponse) (*list.CredentialInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialInfo", ctx, acc)
+	ret0, _ := ret[0].(*list.CredentialInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialInfo indicates an expected call of GetCredentialInfo.
+func (mr *MockCredentialDescriberMockRecorder) GetCredentialInfo(ctx, acc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialInfo", reflect.TypeOf((*MockCredentialDescriber)(nil).GetCredentialInfo), ctx, acc)
+}
+
+// MockAccountVerifier is a mock of AccountVerifier interface.
+type MockAccountVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountVerifierMockRecorder is the mock recorder for MockAccountVerifier.
+type MockAccountVerifierMockRecorder struct {
+	mock *MockAccountVerifier
+}
+
+// NewMockAccountVerifier creates a new mock instance.
+func NewMockAccountVerifier(ctrl *gomock.Controller) *MockAccountVerifier {
+	mock := &MockAccountVerifier{ctrl: ctrl}
+	mock.recorder = &MockAccountVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountVerifier) EXPECT() *MockAccountVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyAccounts mocks base method.
+func (m *MockAccountVerifier) VerifyAccounts(ctx *gofr.Context, id int64) ([]provider.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAccounts", ctx, id)
+	ret0, _ := ret[0].([]provider.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAccounts indicates an expected call of VerifyAccounts.
+func (mr *MockAccountVerifierMockRecorder) VerifyAccounts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAccounts", reflect.TypeOf((*MockAccountVerifier)(nil).VerifyAccounts), ctx, id)
+}
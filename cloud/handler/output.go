@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/service/list"
+	"zop.dev/cli/zop/utils"
+)
+
+// render renders the rows selected with -filter and -sort-by in the format, tables without rows are replaced
+// by the empty message.
+func render[T any](out *utils.Output, rows []T, columns []utils.Column[T], empty string) (string, error) {
+	rows, err := utils.Select(out, rows)
+	if err != nil {
+		return "", err
+	}
+
+	if out.Format == utils.OutputTable && len(rows) == 0 {
+		return empty, nil
+	}
+
+	return utils.Render(out, rows, columns)
+}
+
+func accountColumns(out *utils.Output) []utils.Column[*list.CloudAccountResponse] {
+	return []utils.Column[*list.CloudAccountResponse]{
+		{Header: "ID", Value: func(a *list.CloudAccountResponse) string { return strconv.FormatInt(a.ID, 10) }, Wide: true},
+		{Header: "Name", Value: func(a *list.CloudAccountResponse) string { return a.Name }},
+		{Header: "Provider", Value: func(a *list.CloudAccountResponse) string { return a.Provider }},
+		{Header: "Provider ID", Value: func(a *list.CloudAccountResponse) string { return a.ProviderID }},
+		{Header: "Updated At", Value: func(a *list.CloudAccountResponse) string { return out.FormatTime(a.UpdatedAt) }},
+		{Header: "Created At", Value: func(a *list.CloudAccountResponse) string { return out.FormatTime(a.CreatedAt) }},
+		{Header: "Provider Details", Value: providerDetails, Wide: true},
+	}
+}
+
+func resultColumns() []utils.Column[provider.Result] {
+	return []utils.Column[provider.Result]{
+		{Header: "Provider", Value: func(r provider.Result) string { return r.Provider }},
+		{Header: "Account", Value: func(r provider.Result) string { return r.Account }},
+		{Header: "Project", Value: func(r provider.Result) string { return r.Project }},
+		{Header: "Status", Value: func(r provider.Result) string { return string(r.Status) }},
+		{Header: "Reason", Value: func(r provider.Result) string { return r.Reason }},
+	}
+}
+
+func checkColumns() []utils.Column[provider.Check] {
+	return []utils.Column[provider.Check]{
+		{Header: "Provider", Value: func(c provider.Check) string { return c.Provider }},
+		{Header: "Account", Value: func(c provider.Check) string { return c.Account }},
+		{Header: "Check", Value: func(c provider.Check) string { return c.Check }},
+		{Header: "Status", Value: func(c provider.Check) string { return string(c.Status) }},
+		{Header: "Detail", Value: func(c provider.Check) string { return c.Detail }},
+	}
+}
+
+// providerDetails renders the provider details of the account in a single cell, with secrets redacted.
+func providerDetails(a *list.CloudAccountResponse) string {
+	details := flattenDetails("", a.ProviderDetails, nil)
+
+	pairs := make([]string, len(details))
+	for i, d := range details {
+		pairs[i] = d[0] + "=" + d[1]
+	}
+
+	return strings.Join(pairs, ", ")
+}
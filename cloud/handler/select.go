@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/service/list"
+	"zop.dev/cli/zop/utils"
+)
+
+const selectAccountTitle = "Select the cloud account!"
+
+var (
+	// ErrAccountNotFound is returned when no cloud account in zop api has the id passed with -id.
+	ErrAccountNotFound = errors.New("cloud account not found")
+
+	// ErrUnableToRenderList is returned when the list of cloud accounts cannot be rendered.
+	ErrUnableToRenderList = errors.New("unable to render the list")
+
+	// ErrNoAccountSelected is returned when no cloud account was selected from the list.
+	ErrNoAccountSelected = errors.New("no cloud account selected")
+)
+
+// selectAccount returns the cloud account passed with -id, or the one picked from the list
+// of cloud accounts in zop api when -id is not set.
+func (h *Handler) selectAccount(ctx *gofr.Context) (*list.CloudAccountResponse, error) {
+	id, err := getAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := h.accountGetter.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id != 0 {
+		return findAccount(accounts, id)
+	}
+
+	items := make([]*utils.Item, 0, len(accounts))
+	for _, acc := range accounts {
+		items = append(items, &utils.Item{ID: acc.ID, Name: acc.Name, Data: acc})
+	}
+
+	choice, err := utils.RenderList(selectAccountTitle, items)
+	if err != nil {
+		ctx.Logger.Errorf("unable to render the list of cloud accounts! %v", err)
+
+		return nil, ErrUnableToRenderList
+	}
+
+	if choice == nil || choice.Data == nil {
+		return nil, ErrNoAccountSelected
+	}
+
+	return choice.Data.(*list.CloudAccountResponse), nil
+}
+
+// getAccount fetches the cloud account with the given id from zop api.
+func (h *Handler) getAccount(ctx *gofr.Context, id int64) (*list.CloudAccountResponse, error) {
+	accounts, err := h.accountGetter.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return findAccount(accounts, id)
+}
+
+func findAccount(accounts []*list.CloudAccountResponse, id int64) (*list.CloudAccountResponse, error) {
+	for _, acc := range accounts {
+		if acc.ID == id {
+			return acc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrAccountNotFound, id)
+}
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/service/list"
+	"zop.dev/cli/zop/utils"
+)
+
+const (
+	redacted    = "[redacted]"
+	unavailable = "unavailable"
+
+	sectionProviderDetails = "Provider details"
+	sectionDeploymentSpace = "Deployment spaces"
+)
+
+// sensitiveFields are parts of provider detail names whose values are never printed.
+//
+//nolint:gochecknoglobals //list of field name parts that are redacted
+var sensitiveFields = []string{"secret", "password", "token", "privatekey", "private_key", "credential"}
+
+// accountDetails is everything cloud show knows about a cloud account, it is rendered as is for json and yaml.
+type accountDetails struct {
+	*list.CloudAccountResponse
+
+	ServiceAccount   string                  `json:"serviceAccount,omitempty"`
+	KeyID            string                  `json:"keyId,omitempty"`
+	KeyCreatedAt     *time.Time              `json:"keyCreatedAt,omitempty"`
+	DeploymentSpaces []*list.DeploymentSpace `json:"deploymentSpaces"`
+
+	credentialsErr      bool
+	deploymentSpacesErr bool
+}
+
+// field is a single line of cloud show, fields of a section are listed below the section name.
+type field struct {
+	Section string
+	Name    string
+	Value   string
+}
+
+// Show is a handler for showing the details of a cloud account: the provider details, the service account
+// and the age of its key, the deployment spaces attached to it and its timestamps, formatted with -time-format.
+// The account is selected with -id or picked from a list. Sensitive provider details are redacted.
+// The details are printed as a list of fields, or in the format selected with -output. With -watch the
+// account and its details are fetched and redrawn every -interval, for example while zop api validates the
+// account or provisions a deployment space. The watch shows an error once the account no longer exists.
+func (h *Handler) Show(ctx *gofr.Context) (any, error) {
+	out, err := utils.GetOutput(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := h.selectAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, refresh := acc.ID, false
+
+	return utils.Watch(ctx, func() (any, error) {
+		// Redraws of -watch fetch the account again, zop api may have updated it since.
+		if refresh {
+			if acc, err = h.getAccount(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+
+		refresh = true
+
+		details := h.getDetails(ctx, acc)
+
+		switch out.Format {
+		case utils.OutputJSON, utils.OutputYAML:
+			return utils.RenderValue(out.Format, details)
+		case utils.OutputCSV:
+			return utils.Render(out, details.fields(out), []utils.Column[field]{
+				{Header: "Field", Value: func(f field) string {
+					if f.Section != "" {
+						return f.Section + "." + f.Name
+					}
+
+					return f.Name
+				}},
+				{Header: "Value", Value: func(f field) string { return f.Value }},
+			})
+		default:
+			return formatFields(details.fields(out)), nil
+		}
+	})
+}
+
+// getDetails collects the details of the cloud account. Credentials and deployment spaces that cannot be
+// read are logged and shown as unavailable.
+func (h *Handler) getDetails(ctx *gofr.Context, acc *list.CloudAccountResponse) *accountDetails {
+	redactedAcc := *acc
+	redactedAcc.ProviderDetails = redact(acc.ProviderDetails)
+
+	details := &accountDetails{CloudAccountResponse: &redactedAcc}
+
+	info, err := h.describer.GetCredentialInfo(ctx, acc)
+	if err != nil {
+		ctx.Logger.Errorf("unable to describe the credentials of cloud account %s: %v", acc.Name, err)
+
+		details.credentialsErr = true
+	} else if info != nil {
+		details.ServiceAccount, details.KeyID = info.ServiceAccount, info.KeyID
+
+		if !info.KeyCreatedAt.IsZero() {
+			details.KeyCreatedAt = &info.KeyCreatedAt
+		}
+	}
+
+	details.DeploymentSpaces, err = h.accountGetter.GetDeploymentSpaces(ctx, acc.ID)
+	if err != nil {
+		ctx.Logger.Errorf("unable to fetch the deployment spaces of cloud account %s: %v", acc.Name, err)
+
+		details.deploymentSpacesErr = true
+	}
+
+	return details
+}
+
+// fields lists the details in the order they are shown.
+func (d *accountDetails) fields(out *utils.Output) []field {
+	fields := []field{
+		{Name: "Name", Value: d.Name},
+		{Name: "ID", Value: strconv.FormatInt(d.ID, 10)},
+		{Name: "Provider", Value: d.Provider},
+		{Name: "Provider ID", Value: d.ProviderID},
+	}
+
+	for _, p := range flattenDetails("", d.ProviderDetails, nil) {
+		fields = append(fields, field{Section: sectionProviderDetails, Name: p[0], Value: p[1]})
+	}
+
+	switch {
+	case d.credentialsErr:
+		fields = append(fields, field{Name: "Service account", Value: unavailable})
+	case d.ServiceAccount != "":
+		fields = append(fields, field{Name: "Service account", Value: d.ServiceAccount},
+			field{Name: "Key", Value: d.keyValue(out)})
+	}
+
+	switch {
+	case d.deploymentSpacesErr:
+		fields = append(fields, field{Name: sectionDeploymentSpace, Value: unavailable})
+	case len(d.DeploymentSpaces) == 0:
+		fields = append(fields, field{Name: sectionDeploymentSpace, Value: "none"})
+	}
+
+	for _, s := range d.DeploymentSpaces {
+		value := s.Type
+		if s.Environment != "" {
+			value += ", environment " + s.Environment
+		}
+
+		fields = append(fields, field{Section: sectionDeploymentSpace, Name: s.Name, Value: value})
+	}
+
+	return append(fields,
+		field{Name: "Created", Value: out.FormatTime(d.CreatedAt)},
+		field{Name: "Updated", Value: out.FormatTime(d.UpdatedAt)})
+}
+
+// keyValue describes the key of the service account.
+func (d *accountDetails) keyValue(out *utils.Output) string {
+	switch {
+	case d.KeyID == "":
+		return "none, zop-api impersonates the service account"
+	case d.KeyCreatedAt == nil:
+		return fmt.Sprintf("%s, age %s", d.KeyID, unavailable)
+	default:
+		return fmt.Sprintf("%s, %d days old (created %s)", d.KeyID,
+			int(time.Since(*d.KeyCreatedAt).Hours()/hoursPerDay), out.FormatTime(utils.Timestamp{Time: *d.KeyCreatedAt}))
+	}
+}
+
+// formatFields renders the fields as aligned name and value pairs, with the fields of a section indented
+// below its name.
+func formatFields(fields []field) string {
+	var b strings.Builder
+
+	w := tabwriter.NewWriter(&b, 0, 0, tablePadding, ' ', 0)
+
+	section := ""
+
+	for _, f := range fields {
+		if f.Section == "" {
+			fmt.Fprintf(w, "%s:\t%s\n", f.Name, f.Value)
+
+			continue
+		}
+
+		if f.Section != section {
+			fmt.Fprintf(w, "%s:\t\n", f.Section)
+		}
+
+		fmt.Fprintf(w, "  %s:\t%s\n", f.Name, f.Value)
+
+		section = f.Section
+	}
+
+	_ = w.Flush()
+
+	return b.String()
+}
+
+// redact returns a copy of the provider details with the values of sensitive fields replaced.
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redactedMap := make(map[string]any, len(v))
+
+		for k, item := range v {
+			if isSensitive(k) {
+				redactedMap[k] = redacted
+			} else {
+				redactedMap[k] = redact(item)
+			}
+		}
+
+		return redactedMap
+	case []any:
+		redactedList := make([]any, len(v))
+
+		for i, item := range v {
+			redactedList[i] = redact(item)
+		}
+
+		return redactedList
+	default:
+		return v
+	}
+}
+
+// redactAccounts returns copies of the accounts with the secrets in their provider details redacted.
+func redactAccounts(accounts []*list.CloudAccountResponse) []*list.CloudAccountResponse {
+	redactedAccounts := make([]*list.CloudAccountResponse, len(accounts))
+
+	for i, acc := range accounts {
+		redactedAcc := *acc
+		redactedAcc.ProviderDetails = redact(acc.ProviderDetails)
+
+		redactedAccounts[i] = &redactedAcc
+	}
+
+	return redactedAccounts
+}
+
+// flattenDetails turns the provider details into sorted name and value pairs, nested fields are
+// joined with dots. Values of sensitive fields are redacted.
+func flattenDetails(prefix string, value any, details [][2]string) [][2]string {
+	switch v := value.(type) {
+	case nil:
+		return details
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			name := k
+			if prefix != "" {
+				name = prefix + "." + k
+			}
+
+			if isSensitive(k) {
+				details = append(details, [2]string{name, redacted})
+
+				continue
+			}
+
+			details = flattenDetails(name, v[k], details)
+		}
+
+		return details
+	case []any:
+		for i, item := range v {
+			details = flattenDetails(fmt.Sprintf("%s.%d", prefix, i), item, details)
+		}
+
+		return details
+	default:
+		if prefix == "" {
+			prefix = "details"
+		}
+
+		return append(details, [2]string{prefix, fmt.Sprint(v)})
+	}
+}
+
+// isSensitive reports whether the provider detail with the given name holds a secret.
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, s := range sensitiveFields {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
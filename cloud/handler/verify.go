@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/utils"
+)
+
+const noAccountsVerified = "No accounts found to verify\n"
+
+// ErrVerifyFailed is returned when any check of a cloud account failed, after the checks were printed.
+var ErrVerifyFailed = errors.New("some cloud accounts failed verification")
+
+// Verify is a handler for checking the health of the cloud accounts in zop api. Every account is checked,
+// unless one is selected with -id. The outcome of every check is printed as a table, or in the format
+// selected with -output. If any check failed, the report is printed and an error is returned.
+func (h *Handler) Verify(ctx *gofr.Context) (any, error) {
+	out, err := utils.GetOutput(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := getAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := h.verifier.VerifyAccounts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := render(out, checks, checkColumns(), noAccountsVerified)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider.AnyCheckFailed(checks) {
+		ctx.Out.Print(report)
+
+		return nil, ErrVerifyFailed
+	}
+
+	return report, nil
+}
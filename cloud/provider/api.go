@@ -0,0 +1,16 @@
+package provider
+
+import "fmt"
+
+// ZopAPIService is the name of the http service of zop api the cloud accounts are read from and posted to.
+const ZopAPIService = "api-service"
+
+// ErrAPIService is returned when zop api responds with an unexpected status code.
+type ErrAPIService struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ErrAPIService) Error() string {
+	return fmt.Sprintf("error from api service: %s, status code: %d", e.Message, e.StatusCode)
+}
@@ -0,0 +1,33 @@
+package provider
+
+// CheckStatus is the outcome of a single health check of a cloud account.
+type CheckStatus string
+
+const (
+	// CheckPass means the check found nothing wrong.
+	CheckPass CheckStatus = "pass"
+	// CheckWarn means the check could not be completed or found something worth a look.
+	CheckWarn CheckStatus = "warn"
+	// CheckFail means the cloud account is broken, zop api cannot use it as it is.
+	CheckFail CheckStatus = "fail"
+)
+
+// Check is the outcome of a single health check of a cloud account, like whether its key still works.
+type Check struct {
+	Provider string      `json:"provider"`
+	Account  string      `json:"account"`
+	Check    string      `json:"check"`
+	Status   CheckStatus `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+}
+
+// AnyCheckFailed reports whether any of the checks failed.
+func AnyCheckFailed(checks []Check) bool {
+	for _, c := range checks {
+		if c.Status == CheckFail {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,9 @@
+package provider
+
+import "gofr.dev/pkg/gofr"
+
+// Importer is an interface for importing the local cloud accounts of a provider to zop api.
+// PostAccounts returns the result of every account it found, an error means the accounts could not be read at all.
+type Importer interface {
+	PostAccounts(ctx *gofr.Context) ([]Result, error)
+}
@@ -0,0 +1,160 @@
+// Package provider holds the registry of cloud providers whose local accounts can be imported to zop api.
+// Every provider registers its name, a function discovering its local credentials and its importer,
+// so that a new provider can be added as a self-contained package.
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+)
+
+var (
+	// ErrDuplicateProvider is returned when a provider is registered more than once.
+	ErrDuplicateProvider = errors.New("provider already registered")
+
+	// ErrUnknownProvider is returned when a provider that is not registered is selected.
+	ErrUnknownProvider = errors.New("unknown cloud provider")
+
+	// ErrNoLocalCredentials is returned when a provider is selected but none of its credentials are found locally.
+	ErrNoLocalCredentials = errors.New("no local credentials found")
+)
+
+// DiscoverFunc returns the local credential sources found for a provider, for example the paths of
+// the credential files or of the files given with a flag. An empty result means the provider has nothing to import.
+type DiscoverFunc func(ctx *gofr.Context) []string
+
+// Provider is a cloud provider that can be imported.
+type Provider struct {
+	Name     string
+	Discover DiscoverFunc
+	Importer Importer
+}
+
+// Registry holds the registered providers in the order they were registered.
+type Registry struct {
+	providers []*Provider
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the providers to the registry, provider names must be unique.
+func (r *Registry) Register(providers ...*Provider) error {
+	for _, p := range providers {
+		if _, ok := r.get(p.Name); ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateProvider, p.Name)
+		}
+
+		r.providers = append(r.providers, p)
+	}
+
+	return nil
+}
+
+// Names returns the names of the registered providers.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+
+	for _, p := range r.providers {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+// PostAccounts runs the importers of the given providers, or of every registered provider if none are given,
+// and returns the result of every account. When importing every provider, providers without local credentials
+// are skipped. A failing provider does not stop the import of the others, it is reported as a failed result.
+func (r *Registry) PostAccounts(ctx *gofr.Context, names []string) ([]Result, error) {
+	selected, err := r.selectProviders(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+
+	for _, p := range selected {
+		sources := p.Discover(ctx)
+		if len(sources) == 0 {
+			if len(names) != 0 {
+				results = append(results, Result{Provider: p.Name, Status: StatusFailed, Reason: ErrNoLocalCredentials.Error()})
+			}
+
+			continue
+		}
+
+		ctx.Logger.Debugf("importing %s accounts from %s", p.Name, strings.Join(sources, ", "))
+
+		res, er := p.Importer.PostAccounts(ctx)
+		if er != nil {
+			res = append(res, Result{Status: StatusFailed, Reason: er.Error()})
+		}
+
+		for i := range res {
+			res[i].Provider = p.Name
+		}
+
+		results = append(results, res...)
+	}
+
+	return results, nil
+}
+
+func (r *Registry) selectProviders(names []string) ([]*Provider, error) {
+	if len(names) == 0 {
+		return r.providers, nil
+	}
+
+	selected := make([]*Provider, 0, len(names))
+
+	for _, name := range names {
+		p, ok := r.get(name)
+		if !ok {
+			return nil, fmt.Errorf("%w %q, available providers: %s", ErrUnknownProvider, name, strings.Join(r.Names(), ", "))
+		}
+
+		selected = append(selected, p)
+	}
+
+	return selected, nil
+}
+
+func (r *Registry) get(name string) (*Provider, bool) {
+	for _, p := range r.providers {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return nil, false
+}
+
+// DiscoverFiles returns a DiscoverFunc reporting which of the given files exist.
+func DiscoverFiles(paths ...string) DiscoverFunc {
+	return func(*gofr.Context) []string {
+		found := make([]string, 0, len(paths))
+
+		for _, path := range paths {
+			if _, err := os.Stat(path); err == nil {
+				found = append(found, path)
+			}
+		}
+
+		return found
+	}
+}
+
+// DryRun reports whether the import was started with -dry-run, in which case providers only
+// report what they would import without creating or posting anything.
+func DryRun(ctx *gofr.Context) bool {
+	dryRun, _ := strconv.ParseBool(ctx.Param("dry-run"))
+
+	return dryRun
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+var errImport = errors.New("import error")
+
+func found(sources ...string) DiscoverFunc {
+	return func(*gofr.Context) []string { return sources }
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry()
+
+	require.NoError(t, r.Register(&Provider{Name: "gcp"}, &Provider{Name: "aws"}))
+	require.ErrorIs(t, r.Register(&Provider{Name: "gcp"}), ErrDuplicateProvider)
+	require.Equal(t, []string{"gcp", "aws"}, r.Names())
+}
+
+func TestRegistry_PostAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := &gofr.Context{Container: &container.Container{Logger: logging.NewMockLogger(logging.INFO)}}
+
+	gcp := NewMockImporter(ctrl)
+	aws := NewMockImporter(ctrl)
+	azure := NewMockImporter(ctrl)
+
+	r := NewRegistry()
+	require.NoError(t, r.Register(
+		&Provider{Name: "gcp", Discover: found("credentials.db"), Importer: gcp},
+		&Provider{Name: "aws", Discover: found("credentials"), Importer: aws},
+		&Provider{Name: "azure", Discover: found(), Importer: azure},
+	))
+
+	created := []Result{{Account: "dev@example.com", Status: StatusCreated}}
+
+	testCases := []struct {
+		name      string
+		providers []string
+		mockCalls []*gomock.Call
+		expected  []Result
+		expErr    error
+	}{
+		{
+			name: "all providers with local credentials",
+			mockCalls: []*gomock.Call{
+				gcp.EXPECT().PostAccounts(ctx).Return(created, nil),
+				aws.EXPECT().PostAccounts(ctx).Return([]Result{{Account: "default", Status: StatusExists}}, nil),
+			},
+			expected: []Result{
+				{Provider: "gcp", Account: "dev@example.com", Status: StatusCreated},
+				{Provider: "aws", Account: "default", Status: StatusExists},
+			},
+		},
+		{
+			name: "failing provider does not stop the others",
+			mockCalls: []*gomock.Call{
+				gcp.EXPECT().PostAccounts(ctx).Return(nil, errImport),
+				aws.EXPECT().PostAccounts(ctx).Return(nil, nil),
+			},
+			expected: []Result{{Provider: "gcp", Status: StatusFailed, Reason: errImport.Error()}},
+		},
+		{
+			name:      "selected provider",
+			providers: []string{"aws"},
+			mockCalls: []*gomock.Call{
+				aws.EXPECT().PostAccounts(ctx).Return(nil, nil),
+			},
+		},
+		{
+			name:      "selected provider without local credentials",
+			providers: []string{"azure"},
+			expected:  []Result{{Provider: "azure", Status: StatusFailed, Reason: ErrNoLocalCredentials.Error()}},
+		},
+		{
+			name:      "unknown provider",
+			providers: []string{"gcp", "oracle"},
+			expErr:    ErrUnknownProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := r.PostAccounts(ctx, tc.providers)
+
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expected, results)
+		})
+	}
+}
+
+func TestPosted(t *testing.T) {
+	require.Equal(t, Result{Account: "a", Status: StatusCreated}, Posted("a", "", http.StatusCreated, errImport))
+	require.Equal(t, Result{Account: "a", Project: "p", Status: StatusExists}, Posted("a", "p", http.StatusConflict, errImport))
+	require.Equal(t, Result{Account: "a", Status: StatusFailed, Reason: errImport.Error()},
+		Posted("a", "", http.StatusBadGateway, errImport))
+	require.True(t, AnyFailed([]Result{{Status: StatusCreated}, {Status: StatusFailed}}))
+	require.False(t, AnyFailed([]Result{{Status: StatusSkipped}}))
+}
+
+func TestDiscoverFiles(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "credentials")
+
+	require.NoError(t, os.WriteFile(existing, []byte("[default]"), 0o600))
+
+	require.Equal(t, []string{existing}, DiscoverFiles(existing, filepath.Join(dir, "config"))(&gofr.Context{}))
+}
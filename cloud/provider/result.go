@@ -0,0 +1,67 @@
+package provider
+
+import "net/http"
+
+// Status is the outcome of importing a single cloud account.
+type Status string
+
+const (
+	// StatusCreated means zop api stored the account.
+	StatusCreated Status = "created"
+	// StatusExists means zop api already had the account.
+	StatusExists Status = "exists"
+	// StatusUpdated means zop api already had the account and its credentials were replaced, with -update.
+	StatusUpdated Status = "updated"
+	// StatusUnchanged means zop api already had the account with the same credentials, with -update.
+	StatusUnchanged Status = "unchanged"
+	// StatusSkipped means the account was not imported, for example because it cannot be imported or
+	// zop api already has credentials for it.
+	StatusSkipped Status = "skipped"
+	// StatusFailed means importing the account failed.
+	StatusFailed Status = "failed"
+	// StatusPlanned means the account would be imported, with -dry-run.
+	StatusPlanned Status = "planned"
+)
+
+// Result is the outcome of importing a single cloud account, or a single project of a gcloud user account.
+type Result struct {
+	Provider string `json:"provider"`
+	Account  string `json:"account"`
+	Project  string `json:"project,omitempty"`
+	Status   Status `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Skipped returns the result of an account that was not imported for the given reason.
+func Skipped(account, project string, reason error) Result {
+	return Result{Account: account, Project: project, Status: StatusSkipped, Reason: reason.Error()}
+}
+
+// Failed returns the result of an account whose import failed with err.
+func Failed(account, project string, err error) Result {
+	return Result{Account: account, Project: project, Status: StatusFailed, Reason: err.Error()}
+}
+
+// Posted returns the result of an account posted to zop api, based on the status code of the response.
+// err is used as the reason if zop api neither created the account nor already had it.
+func Posted(account, project string, statusCode int, err error) Result {
+	switch statusCode {
+	case http.StatusCreated:
+		return Result{Account: account, Project: project, Status: StatusCreated}
+	case http.StatusConflict:
+		return Result{Account: account, Project: project, Status: StatusExists}
+	default:
+		return Failed(account, project, err)
+	}
+}
+
+// AnyFailed reports whether the import of any of the accounts failed.
+func AnyFailed(results []Result) bool {
+	for i := range results {
+		if results[i].Status == StatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
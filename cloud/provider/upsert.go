@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+)
+
+var (
+	// ErrAccountNotFound is returned when zop api reports a conflict but has no account of the same name
+	// or provider id to update.
+	ErrAccountNotFound = errors.New("conflicting cloud account not found in zop-api")
+
+	// ErrAmbiguousAccount is returned when more than one account in zop api has the name of the account
+	// being updated and its provider id does not tell them apart.
+	ErrAmbiguousAccount = errors.New("more than one cloud account in zop-api has this name")
+)
+
+// Submission is a cloud account posted to zop api.
+type Submission struct {
+	// Account and Project identify the account in the result.
+	Account string
+	Project string
+
+	// ProviderID is the id of the account at the provider, like the project id of a gcp service account key.
+	// It is used along with the name to find the account in zop api on conflict, and may be empty.
+	ProviderID string
+
+	// Body is the request body posted to zop api, Credentials are the credentials it holds.
+	Body        []byte
+	Credentials any
+}
+
+// Update reports whether the import was started with -update, in which case accounts zop api already has
+// get the new credentials instead of being left as they are.
+func Update(ctx *gofr.Context) bool {
+	update, _ := strconv.ParseBool(ctx.Param("update"))
+
+	return update
+}
+
+// Post posts the cloud account of the provider to zop api and returns its result. When zop api already has
+// the account and the import was started with -update, the existing account is found by provider id or name
+// and its credentials are replaced, unless they did not change.
+func Post(ctx *gofr.Context, providerName string, sub *Submission) Result {
+	resp, err := ctx.GetHTTPService(ZopAPIService).PostWithHeaders(ctx, "cloud-accounts", nil, sub.Body,
+		map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		ctx.Logger.Errorf("error posting account: %v", err)
+
+		return Failed(sub.Account, sub.Project, err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict || !Update(ctx) {
+		return Posted(sub.Account, sub.Project, resp.StatusCode,
+			&ErrAPIService{StatusCode: resp.StatusCode, Message: "could not connect to the zop-api service"})
+	}
+
+	return update(ctx, providerName, sub)
+}
+
+// update replaces the credentials of the account zop api already has.
+func update(ctx *gofr.Context, providerName string, sub *Submission) Result {
+	id, err := findAccount(ctx, providerName, sub)
+	if err != nil {
+		return Failed(sub.Account, sub.Project, err)
+	}
+
+	stored, err := getCredentials(ctx, id)
+	if err != nil {
+		ctx.Logger.Errorf("unable to read the credentials of cloud account %d, updating it: %v", id, err)
+	} else if sameCredentials(stored, sub.Credentials) {
+		return Result{Account: sub.Account, Project: sub.Project, Status: StatusUnchanged}
+	}
+
+	resp, err := ctx.GetHTTPService(ZopAPIService).PutWithHeaders(ctx, fmt.Sprintf("cloud-accounts/%d", id), nil,
+		sub.Body, map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return Failed(sub.Account, sub.Project, err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return Failed(sub.Account, sub.Project,
+			&ErrAPIService{StatusCode: resp.StatusCode, Message: "unable to update the cloud account"})
+	}
+
+	return Result{Account: sub.Account, Project: sub.Project, Status: StatusUpdated}
+}
+
+// findAccount returns the id of the account of the provider in zop api with the provider id of the submission,
+// preferring the one of the same name, or else the only account of the provider with the name of the submission.
+func findAccount(ctx *gofr.Context, providerName string, sub *Submission) (int64, error) {
+	resp, err := ctx.GetHTTPService(ZopAPIService).Get(ctx, "cloud-accounts", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, &ErrAPIService{StatusCode: resp.StatusCode, Message: "unable to list the cloud accounts"}
+	}
+
+	var accounts struct {
+		Data []struct {
+			ID         int64  `json:"id"`
+			Name       string `json:"name"`
+			Provider   string `json:"provider"`
+			ProviderID string `json:"providerId"`
+		} `json:"data"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&accounts); err != nil {
+		return 0, err
+	}
+
+	var byProviderID, byName []int64
+
+	for _, acc := range accounts.Data {
+		if acc.Provider != providerName {
+			continue
+		}
+
+		if sub.ProviderID != "" && acc.ProviderID == sub.ProviderID {
+			if acc.Name == sub.Account {
+				return acc.ID, nil
+			}
+
+			byProviderID = append(byProviderID, acc.ID)
+		}
+
+		if acc.Name == sub.Account {
+			byName = append(byName, acc.ID)
+		}
+	}
+
+	if len(byProviderID) == 1 {
+		return byProviderID[0], nil
+	}
+
+	switch len(byName) {
+	case 0:
+		return 0, ErrAccountNotFound
+	case 1:
+		return byName[0], nil
+	default:
+		return 0, ErrAmbiguousAccount
+	}
+}
+
+// getCredentials returns the credentials zop api holds for the cloud account.
+func getCredentials(ctx *gofr.Context, id int64) (json.RawMessage, error) {
+	resp, err := ctx.GetHTTPService(ZopAPIService).Get(ctx, fmt.Sprintf("cloud-accounts/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &ErrAPIService{StatusCode: resp.StatusCode, Message: "unable to fetch the cloud account"}
+	}
+
+	var account struct {
+		Data struct {
+			Credentials json.RawMessage `json:"credentials"`
+		} `json:"data"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&account); err != nil {
+		return nil, err
+	}
+
+	return account.Data.Credentials, nil
+}
+
+// sameCredentials reports whether the stored credentials are the same as the new ones, ignoring formatting.
+func sameCredentials(stored json.RawMessage, credentials any) bool {
+	b, err := json.Marshal(credentials)
+	if err != nil {
+		return false
+	}
+
+	var old, updated any
+
+	if json.Unmarshal(stored, &old) != nil || json.Unmarshal(b, &updated) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(old, updated)
+}
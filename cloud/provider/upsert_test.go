@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/service"
+)
+
+func response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBufferString(body))}
+}
+
+func TestPost(t *testing.T) {
+	const (
+		accounts = `{"data": [{"id": 1, "name": "ci", "provider": "gcp", "providerId": "search"},` +
+			`{"id": 2, "name": "ci", "provider": "gcp", "providerId": "payments"}]}`
+		other  = `{"data": [{"id": 3, "name": "ci", "provider": "aws", "providerId": "123456789012"}]}`
+		stored = `{"data": {"credentials": {"client_email": "ci@payments.iam.gserviceaccount.com", "private_key_id": "old"}}}`
+	)
+
+	sub := &Submission{Account: "ci", ProviderID: "payments", Body: []byte(`{}`),
+		Credentials: map[string]string{"client_email": "ci@payments.iam.gserviceaccount.com", "private_key_id": "new"}}
+	unchanged := &Submission{Account: "ci", ProviderID: "payments", Body: []byte(`{}`),
+		Credentials: map[string]string{"private_key_id": "old", "client_email": "ci@payments.iam.gserviceaccount.com"}}
+	unknown := &Submission{Account: "ci", Body: []byte(`{}`), Credentials: map[string]string{}}
+
+	testCases := []struct {
+		name     string
+		args     []string
+		sub      *Submission
+		mocks    func(m *service.MockHTTP)
+		expected Result
+	}{
+		{
+			name: "created",
+			args: []string{""},
+			sub:  sub,
+			mocks: func(m *service.MockHTTP) {
+				m.EXPECT().PostWithHeaders(gomock.Any(), "cloud-accounts", nil, sub.Body, gomock.Any()).
+					Return(response(http.StatusCreated, ""), nil)
+			},
+			expected: Result{Account: "ci", Status: StatusCreated},
+		},
+		{
+			name: "conflict without update",
+			args: []string{""},
+			sub:  sub,
+			mocks: func(m *service.MockHTTP) {
+				m.EXPECT().PostWithHeaders(gomock.Any(), "cloud-accounts", nil, sub.Body, gomock.Any()).
+					Return(response(http.StatusConflict, ""), nil)
+			},
+			expected: Result{Account: "ci", Status: StatusExists},
+		},
+		{
+			name: "conflict with update",
+			args: []string{"", "-update"},
+			sub:  sub,
+			mocks: func(m *service.MockHTTP) {
+				m.EXPECT().PostWithHeaders(gomock.Any(), "cloud-accounts", nil, sub.Body, gomock.Any()).
+					Return(response(http.StatusConflict, ""), nil)
+				m.EXPECT().Get(gomock.Any(), "cloud-accounts", nil).Return(response(http.StatusOK, accounts), nil)
+				m.EXPECT().Get(gomock.Any(), "cloud-accounts/2", nil).Return(response(http.StatusOK, stored), nil)
+				m.EXPECT().PutWithHeaders(gomock.Any(), "cloud-accounts/2", nil, sub.Body, gomock.Any()).
+					Return(response(http.StatusOK, ""), nil)
+			},
+			expected: Result{Account: "ci", Status: StatusUpdated},
+		},
+		{
+			name: "same credentials",
+			args: []string{"", "-update"},
+			sub:  unchanged,
+			mocks: func(m *service.MockHTTP) {
+				m.EXPECT().PostWithHeaders(gomock.Any(), "cloud-accounts", nil, unchanged.Body, gomock.Any()).
+					Return(response(http.StatusConflict, ""), nil)
+				m.EXPECT().Get(gomock.Any(), "cloud-accounts", nil).Return(response(http.StatusOK, accounts), nil)
+				m.EXPECT().Get(gomock.Any(), "cloud-accounts/2", nil).Return(response(http.StatusOK, stored), nil)
+			},
+			expected: Result{Account: "ci", Status: StatusUnchanged},
+		},
+		{
+			name: "ambiguous name",
+			args: []string{"", "-update"},
+			sub:  unknown,
+			mocks: func(m *service.MockHTTP) {
+				m.EXPECT().PostWithHeaders(gomock.Any(), "cloud-accounts", nil, unknown.Body, gomock.Any()).
+					Return(response(http.StatusConflict, ""), nil)
+				m.EXPECT().Get(gomock.Any(), "cloud-accounts", nil).Return(response(http.StatusOK, accounts), nil)
+			},
+			expected: Result{Account: "ci", Status: StatusFailed, Reason: ErrAmbiguousAccount.Error()},
+		},
+		{
+			name: "name of another provider",
+			args: []string{"", "-update"},
+			sub:  unknown,
+			mocks: func(m *service.MockHTTP) {
+				m.EXPECT().PostWithHeaders(gomock.Any(), "cloud-accounts", nil, unknown.Body, gomock.Any()).
+					Return(response(http.StatusConflict, ""), nil)
+				m.EXPECT().Get(gomock.Any(), "cloud-accounts", nil).Return(response(http.StatusOK, other), nil)
+			},
+			expected: Result{Account: "ci", Status: StatusFailed, Reason: ErrAccountNotFound.Error()},
+		},
+		{
+			name: "list failure",
+			args: []string{"", "-update"},
+			sub:  sub,
+			mocks: func(m *service.MockHTTP) {
+				m.EXPECT().PostWithHeaders(gomock.Any(), "cloud-accounts", nil, sub.Body, gomock.Any()).
+					Return(response(http.StatusConflict, ""), nil)
+				m.EXPECT().Get(gomock.Any(), "cloud-accounts", nil).Return(response(http.StatusInternalServerError, "{}"), nil)
+			},
+			expected: Result{Account: "ci", Status: StatusFailed,
+				Reason: (&ErrAPIService{StatusCode: http.StatusInternalServerError, Message: "unable to list the cloud accounts"}).Error()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+				return service.NewMockHTTP(ctrl)
+			})
+			mockCont.Services[ZopAPIService] = mocks.HTTPService
+
+			tc.mocks(mocks.HTTPService)
+
+			ctx := &gofr.Context{Container: mockCont, Request: cmd.NewRequest(tc.args)}
+
+			require.Equal(t, tc.expected, Post(ctx, "gcp", tc.sub))
+		})
+	}
+}
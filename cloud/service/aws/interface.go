@@ -0,0 +1,12 @@
+package aws
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/store/aws"
+)
+
+// ProfileStore is an interface for getting the AWS profiles from the store layer.
+type ProfileStore interface {
+	GetProfiles(ctx *gofr.Context) ([]aws.Profile, error)
+}
@@ -0,0 +1,32 @@
+package aws
+
+const (
+	credentialTypeStatic     = "static"
+	credentialTypeAssumeRole = "assume_role"
+	credentialTypeSSO        = "sso"
+)
+
+// credentials is a struct for storing the resolved credentials of an AWS profile.
+// Profiles assuming a role carry the credentials of their source profile in Source.
+type credentials struct {
+	Type            string       `json:"type"`
+	AccessKeyID     string       `json:"access_key_id,omitempty"`
+	SecretAccessKey string       `json:"secret_access_key,omitempty"`
+	SessionToken    string       `json:"session_token,omitempty"`
+	Region          string       `json:"region,omitempty"`
+	RoleARN         string       `json:"role_arn,omitempty"`
+	ExternalID      string       `json:"external_id,omitempty"`
+	RoleSessionName string       `json:"role_session_name,omitempty"`
+	SSOStartURL     string       `json:"sso_start_url,omitempty"`
+	SSORegion       string       `json:"sso_region,omitempty"`
+	SSOAccountID    string       `json:"sso_account_id,omitempty"`
+	SSORoleName     string       `json:"sso_role_name,omitempty"`
+	Source          *credentials `json:"source,omitempty"`
+}
+
+// request is a struct for forming the request body for posting cloud accounts to zop api.
+type request struct {
+	Name        string `json:"name"`
+	Provider    string `json:"provider"`
+	Credentials any    `json:"credentials"`
+}
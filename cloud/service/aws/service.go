@@ -0,0 +1,195 @@
+// Package aws provides a service for importing the AWS profiles configured on the local system into zop api service.
+package aws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd/terminal"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/store/aws"
+	"zop.dev/cli/zop/utils"
+)
+
+const providerName = "aws"
+
+var (
+	// ErrProfileNotFound is returned when a profile refers to a source profile that is not configured.
+	ErrProfileNotFound = errors.New("profile not found")
+
+	// ErrProfileCycle is returned when the source_profile chain of a profile refers back to itself.
+	ErrProfileCycle = errors.New("source_profile chain contains a cycle")
+
+	// ErrNoCredentials is returned when a profile has no static keys, role or sso configuration.
+	ErrNoCredentials = errors.New("profile has no static keys, role or sso configuration")
+
+	// ErrUnsupportedCredentialSource is returned when a role is assumed using a credential_source,
+	// which is only available on the machine the profile was configured for.
+	ErrUnsupportedCredentialSource = errors.New("credential_source is not supported, use source_profile instead")
+)
+
+// Service is a service for importing AWS profiles into zop api service.
+type Service struct {
+	store ProfileStore
+}
+
+// New creates a new Service to import the profiles read by the given store.
+func New(store ProfileStore) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+// NewProvider returns the aws provider for the registry, importing the profiles read by the store
+// from the shared credentials and config files.
+func NewProvider(store ProfileStore, credentialsPath, configPath string) *provider.Provider {
+	return &provider.Provider{
+		Name:     providerName,
+		Discover: provider.DiscoverFiles(credentialsPath, configPath),
+		Importer: New(store),
+	}
+}
+
+// PostAccounts posts the AWS profiles to the api service.
+// Static keys are posted as is, role profiles are posted along with the resolved credentials
+// of their source_profile chain and SSO profiles are posted with their IAM Identity Center settings.
+// With -dry-run it only prints the profiles that would be posted. It returns the result of every profile.
+func (s *Service) PostAccounts(ctx *gofr.Context) ([]provider.Result, error) {
+	profiles, err := s.store.GetProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*aws.Profile, len(profiles))
+	for i := range profiles {
+		byName[profiles[i].Name] = &profiles[i]
+	}
+
+	dryRun := provider.DryRun(ctx)
+
+	if !dryRun && utils.Interactive(ctx) {
+		defer terminal.NewDotSpinner(ctx.Out).Spin(ctx).Stop()
+	}
+
+	results := make([]provider.Result, 0, len(profiles))
+
+	for i := range profiles {
+		creds, er := resolveCredentials(&profiles[i], byName, make(map[string]bool))
+		if er != nil {
+			ctx.Logger.Errorf("skipping aws profile %s: %v", profiles[i].Name, er)
+
+			results = append(results, provider.Skipped(profiles[i].Name, "", er))
+
+			continue
+		}
+
+		if dryRun {
+			results = append(results, provider.Result{Account: profiles[i].Name, Status: provider.StatusPlanned,
+				Reason: fmt.Sprintf("%s credentials, would be posted", creds.Type)})
+
+			continue
+		}
+
+		body, er := json.Marshal(&request{
+			Name:        profiles[i].Name,
+			Provider:    providerName,
+			Credentials: creds,
+		})
+		if er != nil {
+			ctx.Logger.Errorf("error marshaling account creds: %v", er)
+
+			results = append(results, provider.Failed(profiles[i].Name, "", er))
+
+			continue
+		}
+
+		results = append(results, provider.Post(ctx, providerName, &provider.Submission{
+			Account:     profiles[i].Name,
+			Body:        body,
+			Credentials: creds,
+		}))
+	}
+
+	return results, nil
+}
+
+// resolveCredentials resolves the credentials of a profile, following its source_profile chain.
+// visited holds the profiles already seen in the chain to detect cycles.
+func resolveCredentials(p *aws.Profile, profiles map[string]*aws.Profile, visited map[string]bool) (*credentials, error) {
+	if visited[p.Name] {
+		return nil, fmt.Errorf("%w: %s", ErrProfileCycle, p.Name)
+	}
+
+	visited[p.Name] = true
+
+	switch {
+	case p.RoleARN != "":
+		return resolveRole(p, profiles, visited)
+	case p.SSOStartURL != "" && p.SSOAccountID != "":
+		return &credentials{
+			Type:         credentialTypeSSO,
+			Region:       p.Region,
+			SSOStartURL:  p.SSOStartURL,
+			SSORegion:    p.SSORegion,
+			SSOAccountID: p.SSOAccountID,
+			SSORoleName:  p.SSORoleName,
+		}, nil
+	case p.AccessKeyID != "" && p.SecretAccessKey != "":
+		return &credentials{
+			Type:            credentialTypeStatic,
+			AccessKeyID:     p.AccessKeyID,
+			SecretAccessKey: p.SecretAccessKey,
+			SessionToken:    p.SessionToken,
+			Region:          p.Region,
+		}, nil
+	default:
+		return nil, ErrNoCredentials
+	}
+}
+
+func resolveRole(p *aws.Profile, profiles map[string]*aws.Profile, visited map[string]bool) (*credentials, error) {
+	if p.SourceProfile == "" {
+		return nil, ErrUnsupportedCredentialSource
+	}
+
+	source, ok := profiles[p.SourceProfile]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, p.SourceProfile)
+	}
+
+	var (
+		sourceCreds *credentials
+		err         error
+	)
+
+	// A profile may assume the role using its own static keys.
+	if source.Name == p.Name {
+		if p.AccessKeyID == "" || p.SecretAccessKey == "" {
+			return nil, ErrNoCredentials
+		}
+
+		sourceCreds = &credentials{
+			Type:            credentialTypeStatic,
+			AccessKeyID:     p.AccessKeyID,
+			SecretAccessKey: p.SecretAccessKey,
+			SessionToken:    p.SessionToken,
+		}
+	} else {
+		sourceCreds, err = resolveCredentials(source, profiles, visited)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &credentials{
+		Type:            credentialTypeAssumeRole,
+		Region:          p.Region,
+		RoleARN:         p.RoleARN,
+		ExternalID:      p.ExternalID,
+		RoleSessionName: p.RoleSessionName,
+		Source:          sourceCreds,
+	}, nil
+}
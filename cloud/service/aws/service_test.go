@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zop.dev/cli/zop/cloud/store/aws"
+)
+
+func Test_resolveCredentials(t *testing.T) {
+	profiles := map[string]*aws.Profile{
+		"base":     {Name: "base", AccessKeyID: "AKIABASE", SecretAccessKey: "secret"},
+		"admin":    {Name: "admin", RoleARN: "arn:aws:iam::1:role/admin", SourceProfile: "base"},
+		"chained":  {Name: "chained", RoleARN: "arn:aws:iam::2:role/ops", SourceProfile: "admin", Region: "eu-west-1"},
+		"self":     {Name: "self", RoleARN: "arn:aws:iam::3:role/self", SourceProfile: "self", AccessKeyID: "AKIASELF", SecretAccessKey: "s"},
+		"loop-a":   {Name: "loop-a", RoleARN: "arn:aws:iam::4:role/a", SourceProfile: "loop-b"},
+		"loop-b":   {Name: "loop-b", RoleARN: "arn:aws:iam::4:role/b", SourceProfile: "loop-a"},
+		"orphan":   {Name: "orphan", RoleARN: "arn:aws:iam::5:role/x", SourceProfile: "missing"},
+		"instance": {Name: "instance", RoleARN: "arn:aws:iam::6:role/x", CredentialSource: "Ec2InstanceMetadata"},
+		"sso":      {Name: "sso", SSOStartURL: "https://corp.awsapps.com/start", SSOAccountID: "7", SSORoleName: "Dev"},
+		"empty":    {Name: "empty", Region: "us-east-1"},
+	}
+
+	base := &credentials{Type: credentialTypeStatic, AccessKeyID: "AKIABASE", SecretAccessKey: "secret"}
+
+	testCases := []struct {
+		profile  string
+		expected *credentials
+		expErr   error
+	}{
+		{profile: "base", expected: base},
+		{profile: "admin", expected: &credentials{Type: credentialTypeAssumeRole, RoleARN: "arn:aws:iam::1:role/admin", Source: base}},
+		{profile: "chained", expected: &credentials{Type: credentialTypeAssumeRole, RoleARN: "arn:aws:iam::2:role/ops", Region: "eu-west-1",
+			Source: &credentials{Type: credentialTypeAssumeRole, RoleARN: "arn:aws:iam::1:role/admin", Source: base}}},
+		{profile: "self", expected: &credentials{Type: credentialTypeAssumeRole, RoleARN: "arn:aws:iam::3:role/self",
+			Source: &credentials{Type: credentialTypeStatic, AccessKeyID: "AKIASELF", SecretAccessKey: "s"}}},
+		{profile: "sso", expected: &credentials{Type: credentialTypeSSO, SSOStartURL: "https://corp.awsapps.com/start",
+			SSOAccountID: "7", SSORoleName: "Dev"}},
+		{profile: "loop-a", expErr: ErrProfileCycle},
+		{profile: "orphan", expErr: ErrProfileNotFound},
+		{profile: "instance", expErr: ErrUnsupportedCredentialSource},
+		{profile: "empty", expErr: ErrNoCredentials},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.profile, func(t *testing.T) {
+			creds, err := resolveCredentials(profiles[tc.profile], profiles, make(map[string]bool))
+
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expected, creds)
+		})
+	}
+}
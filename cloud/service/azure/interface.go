@@ -0,0 +1,13 @@
+package azure
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/store/azure"
+)
+
+// AccountStore is an interface for getting the az cli subscriptions and service principals from the store layer.
+type AccountStore interface {
+	GetSubscriptions(ctx *gofr.Context) ([]azure.Subscription, error)
+	GetServicePrincipals(ctx *gofr.Context) ([]azure.ServicePrincipal, error)
+}
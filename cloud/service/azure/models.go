@@ -0,0 +1,18 @@
+package azure
+
+// credentials is a struct for storing the service principal credentials of a subscription.
+type credentials struct {
+	SubscriptionID    string `json:"subscription_id"`
+	TenantID          string `json:"tenant_id"`
+	ClientID          string `json:"client_id"`
+	ClientSecret      string `json:"client_secret,omitempty"`
+	ClientCertificate string `json:"client_certificate,omitempty"`
+	EnvironmentName   string `json:"environment_name,omitempty"`
+}
+
+// request is a struct for forming the request body for posting cloud accounts to zop api.
+type request struct {
+	Name        string `json:"name"`
+	Provider    string `json:"provider"`
+	Credentials any    `json:"credentials"`
+}
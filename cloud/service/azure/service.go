@@ -0,0 +1,156 @@
+// Package azure provides a service for importing the subscriptions of the az cli into zop api service.
+package azure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd/terminal"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/store/azure"
+	"zop.dev/cli/zop/utils"
+)
+
+const (
+	providerName = "azure"
+
+	stateEnabled         = "Enabled"
+	userTypeSvcPrincipal = "servicePrincipal"
+)
+
+var (
+	// ErrUserLogin is returned for subscriptions accessed through a user login, as user tokens of the az cli
+	// cannot be shared with zop api. Users are advised to log in with a service principal instead.
+	ErrUserLogin = errors.New("subscription is accessed with a user login, " +
+		"run az login --service-principal to import it")
+
+	// ErrServicePrincipalNotFound is returned when the credentials of the service principal of
+	// a subscription are not present locally.
+	ErrServicePrincipalNotFound = errors.New("service principal credentials not found")
+
+	// ErrSubscriptionDisabled is returned for subscriptions that are not in the Enabled state.
+	ErrSubscriptionDisabled = errors.New("subscription is not enabled")
+)
+
+// Service is a service for importing Azure subscriptions into zop api service.
+type Service struct {
+	store AccountStore
+}
+
+// New creates a new Service to import the subscriptions read by the given store.
+func New(store AccountStore) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+// NewProvider returns the azure provider for the registry, importing the subscriptions read by the store
+// from the az cli configuration directory configDir.
+func NewProvider(store AccountStore, configDir string) *provider.Provider {
+	return &provider.Provider{
+		Name:     providerName,
+		Discover: provider.DiscoverFiles(filepath.Join(configDir, "azureProfile.json")),
+		Importer: New(store),
+	}
+}
+
+// PostAccounts posts every subscription the az cli has service principal credentials for to the api service.
+// Subscriptions accessed through a user login are skipped, their tokens cannot be used by zop api.
+// With -dry-run it only prints the subscriptions that would be posted. It returns the result of every subscription.
+func (s *Service) PostAccounts(ctx *gofr.Context) ([]provider.Result, error) {
+	subs, err := s.store.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sps, err := s.store.GetServicePrincipals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun := provider.DryRun(ctx)
+
+	if !dryRun && utils.Interactive(ctx) {
+		defer terminal.NewDotSpinner(ctx.Out).Spin(ctx).Stop()
+	}
+
+	results := make([]provider.Result, 0, len(subs))
+
+	for i := range subs {
+		creds, er := getCredentials(&subs[i], sps)
+		if er != nil {
+			ctx.Logger.Errorf("skipping azure subscription %s (%s): %v", subs[i].Name, subs[i].ID, er)
+
+			results = append(results, provider.Skipped(subs[i].Name, "", er))
+
+			continue
+		}
+
+		if dryRun {
+			results = append(results, provider.Result{Account: subs[i].Name, Status: provider.StatusPlanned,
+				Reason: fmt.Sprintf("service principal %s, would be posted", creds.ClientID)})
+
+			continue
+		}
+
+		body, er := json.Marshal(&request{
+			Name:        subs[i].Name,
+			Provider:    providerName,
+			Credentials: creds,
+		})
+		if er != nil {
+			ctx.Logger.Errorf("error marshaling account creds: %v", er)
+
+			results = append(results, provider.Failed(subs[i].Name, "", er))
+
+			continue
+		}
+
+		results = append(results, provider.Post(ctx, providerName, &provider.Submission{
+			Account:     subs[i].Name,
+			ProviderID:  subs[i].ID,
+			Body:        body,
+			Credentials: creds,
+		}))
+	}
+
+	return results, nil
+}
+
+// getCredentials returns the credentials of the service principal used by the az cli for the subscription.
+func getCredentials(sub *azure.Subscription, sps []azure.ServicePrincipal) (*credentials, error) {
+	if sub.State != stateEnabled {
+		return nil, ErrSubscriptionDisabled
+	}
+
+	if sub.User.Type != userTypeSvcPrincipal {
+		return nil, ErrUserLogin
+	}
+
+	for i := range sps {
+		sp := &sps[i]
+
+		if sp.ClientID != sub.User.Name || sp.TenantID != sub.TenantID {
+			continue
+		}
+
+		if sp.ClientSecret == "" && sp.Certificate == "" {
+			break
+		}
+
+		return &credentials{
+			SubscriptionID:    sub.ID,
+			TenantID:          sub.TenantID,
+			ClientID:          sp.ClientID,
+			ClientSecret:      sp.ClientSecret,
+			ClientCertificate: sp.Certificate,
+			EnvironmentName:   sub.EnvironmentName,
+		}, nil
+	}
+
+	return nil, ErrServicePrincipalNotFound
+}
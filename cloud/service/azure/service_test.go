@@ -0,0 +1,62 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zop.dev/cli/zop/cloud/store/azure"
+)
+
+func Test_getCredentials(t *testing.T) {
+	sps := []azure.ServicePrincipal{
+		{ClientID: "client-1", TenantID: "tenant-1", ClientSecret: "secret-1"},
+		{ClientID: "client-2", TenantID: "tenant-2"},
+	}
+
+	testCases := []struct {
+		name     string
+		sub      azure.Subscription
+		expected *credentials
+		expErr   error
+	}{
+		{
+			name: "service principal with secret",
+			sub: azure.Subscription{ID: "sub-1", State: "Enabled", TenantID: "tenant-1", EnvironmentName: "AzureCloud",
+				User: azure.User{Name: "client-1", Type: "servicePrincipal"}},
+			expected: &credentials{SubscriptionID: "sub-1", TenantID: "tenant-1", ClientID: "client-1",
+				ClientSecret: "secret-1", EnvironmentName: "AzureCloud"},
+		},
+		{
+			name:   "user login",
+			sub:    azure.Subscription{ID: "sub-2", State: "Enabled", User: azure.User{Name: "dev@example.com", Type: "user"}},
+			expErr: ErrUserLogin,
+		},
+		{
+			name:   "disabled subscription",
+			sub:    azure.Subscription{ID: "sub-3", State: "Disabled", User: azure.User{Name: "client-1", Type: "servicePrincipal"}},
+			expErr: ErrSubscriptionDisabled,
+		},
+		{
+			name: "service principal without secret",
+			sub: azure.Subscription{ID: "sub-4", State: "Enabled", TenantID: "tenant-2",
+				User: azure.User{Name: "client-2", Type: "servicePrincipal"}},
+			expErr: ErrServicePrincipalNotFound,
+		},
+		{
+			name: "service principal of another tenant",
+			sub: azure.Subscription{ID: "sub-5", State: "Enabled", TenantID: "tenant-9",
+				User: azure.User{Name: "client-1", Type: "servicePrincipal"}},
+			expErr: ErrServicePrincipalNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			creds, err := getCredentials(&tc.sub, sps)
+
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expected, creds)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -16,30 +17,77 @@ import (
 	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/option"
+
+	"zop.dev/cli/zop/cloud/store/gcp"
+)
+
+const (
+	// serviceAccountPrefix and serviceAccountDescription identify the service accounts created by zop.
+	serviceAccountPrefix      = "zop-dev-"
+	serviceAccountDescription = "Service account for ZOP"
 )
 
+// errServiceAccountExists is returned when the service account to create is already present in the project.
+var errServiceAccountExists = errors.New("already exists")
+
 type serviceAccountConfig struct {
 	ProjectID          string
 	ServiceAccountName string
 	Roles              []string
+	// VerifyRoles reads the IAM policy of the project again after the roles were assigned.
+	VerifyRoles bool
+	// Keyless imports the service account without creating a key, even where keys can be created.
+	Keyless bool
+	// APIServiceAccount is the service account of zop api, allowed to impersonate keyless service accounts.
+	APIServiceAccount string
 }
 
-func getServiceAccounts(ctx *gofr.Context, value []byte) ([]*serviceAccountCreds, error) {
-	var acc serviceAccountCreds
+// accountPlan holds what is imported for a gcloud account. Service account keys, federation configs and
+// impersonated service accounts are imported as is, for a user account a new service account with the
+// roles of roleProfile is created in each of the projects.
+type accountPlan struct {
+	accountID   string
+	credential  *credential
+	projects    []*cloudresourcemanager.Project
+	roleProfile *roleProfile
+}
 
-	err := json.Unmarshal(value, &acc)
+// newAccountPlan parses the credentials of a gcloud account based on their type, fetching the projects
+// a user account has access to that match the filter.
+func newAccountPlan(ctx *gofr.Context, acc *gcp.AccountStore, profile *roleProfile, filter *projectFilter) (*accountPlan, error) {
+	credType, err := credentialType(acc.Value)
 	if err != nil {
 		return nil, err
 	}
 
-	if acc.PrivateKey == "" {
-		return generateNewServiceAccount(ctx, value)
+	var cred *credential
+
+	switch credType {
+	case credTypeServiceAccount:
+		cred, err = newServiceAccountCredential(acc.Value)
+	case credTypeExternal:
+		cred, err = newExternalCredential(acc.Value)
+	case credTypeImpersonated:
+		cred, err = newImpersonatedCredential(acc.Value)
+	case credTypeAuthorizedUser:
+		projects, er := getUserProjects(ctx, acc.Value, filter)
+		if er != nil {
+			return nil, er
+		}
+
+		return &accountPlan{accountID: acc.AccountID, projects: projects, roleProfile: profile}, nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedCredentialType, credType)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
-	return []*serviceAccountCreds{&acc}, nil
+	return &accountPlan{accountID: acc.AccountID, credential: cred}, nil
 }
 
-func generateNewServiceAccount(ctx *gofr.Context, value []byte) ([]*serviceAccountCreds, error) {
+func getUserProjects(ctx *gofr.Context, value []byte, filter *projectFilter) ([]*cloudresourcemanager.Project, error) {
 	var acc userAccountCreds
 
 	err := json.Unmarshal(value, &acc)
@@ -52,86 +100,196 @@ func generateNewServiceAccount(ctx *gofr.Context, value []byte) ([]*serviceAccou
 		return nil, ErrInvalidOrExpiredToken
 	}
 
-	projects, err := fetchProjects(ctx, acc.ClientID, acc.ClientSecret, token)
+	return fetchProjects(ctx, acc.ClientID, acc.ClientSecret, token, filter)
+}
+
+// provisionServiceAccount creates a key with the roles of the config for the zop service account of the project.
+// A zop service account left by an earlier import is reused, a new service account is only created when the
+// project has none. Where the organization policy forbids service account keys, or with -keyless, no key is
+// created: zop api's service account is allowed to impersonate the service account instead and keyless
+// credentials are returned. Every resource it creates and every role or permission it grants is registered
+// in undo, so that it can be removed again if a later step fails. Each step is reported to progress before it starts.
+func provisionServiceAccount(ctx *gofr.Context, config *serviceAccountConfig, undo *rollback,
+	progress func(step string)) (any, error) {
+	progress(stepCreateServiceAccount)
+
+	serviceAccount, created, err := getOrCreateServiceAccount(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return getNewServiceAccounts(ctx, projects)
-}
+	if created {
+		undo.add("service account "+serviceAccount.Email, func() error {
+			return deleteServiceAccount(ctx, serviceAccount.Name)
+		})
+	}
 
-func getNewServiceAccounts(ctx *gofr.Context, projects []*cloudresourcemanager.Project) ([]*serviceAccountCreds, error) {
-	var serviceAccounts = make([]*serviceAccountCreds, 0)
+	keyless := config.Keyless || keyCreationDisabled(ctx, config.ProjectID)
 
-	for _, project := range projects {
-		projectID := project.ProjectId
-		serviceAccountName := fmt.Sprintf("zop-dev-%v", time.Now().Unix())
-		config := newServiceAccountConfig(projectID, serviceAccountName)
+	var creds any
 
-		serviceAccount, err := createServiceAccount(ctx, config)
-		if err != nil {
-			ctx.Logger.Errorf("Failed to create service account for projectID %s : %v", projectID, err)
-			continue
-		}
+	if !keyless {
+		progress(stepCreateKey)
+
+		creds, err = createKeyCredentials(ctx, serviceAccount, undo)
 
-		key, err := createServiceAccountKey(ctx, serviceAccount)
-		if err != nil {
-			ctx.Logger.Errorf("Failed to create service account key for projectID %s : %v", projectID, err)
-			continue
+		switch {
+		case isKeyCreationDisabled(err):
+			keyless = true
+		case err != nil:
+			return nil, err
 		}
+	}
+
+	if keyless {
+		if config.APIServiceAccount == "" {
+			if config.Keyless {
+				return nil, ErrNoAPIServiceAccount
+			}
 
-		decodedKey, err := base64.StdEncoding.DecodeString(string(key))
-		if err != nil {
-			ctx.Logger.Errorf("Failed to decode Base64 string: %v", err)
-			continue
+			return nil, fmt.Errorf("%w, %w", ErrKeyCreationDisabled, ErrNoAPIServiceAccount)
 		}
 
-		if err = assignRoles(ctx, config, serviceAccount); err != nil {
-			ctx.Logger.Errorf("Failed to assign roles: %v", err)
-			continue
+		ctx.Logger.Infof("importing %s in keyless mode, %s may impersonate it", serviceAccount.Email, config.APIServiceAccount)
+
+		progress(stepGrantImpersonation)
+
+		granted, er := grantTokenCreator(ctx, serviceAccount, config.APIServiceAccount)
+		if er != nil {
+			return nil, er
 		}
 
-		var svAcc serviceAccountCreds
+		if granted {
+			grant := fmt.Sprintf("permission of %s to impersonate %s", config.APIServiceAccount, serviceAccount.Email)
 
-		err = json.Unmarshal(decodedKey, &svAcc)
-		if err != nil {
-			ctx.Logger.Errorf("Failed to unmarshal service account key: %v", err)
-			continue
+			undo.add(grant, func() error {
+				return revokeTokenCreator(ctx, serviceAccount.Name, config.APIServiceAccount)
+			})
 		}
 
-		serviceAccounts = append(serviceAccounts, &svAcc)
+		creds = newKeylessCreds(serviceAccount.Email)
+	}
+
+	progress(stepBindRoles)
+
+	added, err := assignRoles(ctx, config, serviceAccount)
+	if len(added) != 0 {
+		bindings := fmt.Sprintf("bindings of %s to %s in project %s",
+			serviceAccount.Email, strings.Join(added, ", "), config.ProjectID)
+
+		undo.add(bindings, func() error {
+			return removeRoles(ctx, config.ProjectID, "serviceAccount:"+serviceAccount.Email, added)
+		})
 	}
 
-	return serviceAccounts, nil
+	if err != nil {
+		return nil, err
+	}
+
+	return creds, nil
 }
 
-func newServiceAccountConfig(projectID, serviceAccountName string) *serviceAccountConfig {
+// createKeyCredentials creates a key for the service account and returns it as service account credentials.
+func createKeyCredentials(ctx *gofr.Context, serviceAccount *iam.ServiceAccount, undo *rollback) (*serviceAccountCreds, error) {
+	key, err := createServiceAccountKey(ctx, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	undo.add("service account key "+key.Name, func() error {
+		return deleteServiceAccountKey(ctx, key.Name)
+	})
+
+	decodedKey, err := base64.StdEncoding.DecodeString(key.PrivateKeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode service account key")
+	}
+
+	var svAcc serviceAccountCreds
+
+	if err = json.Unmarshal(decodedKey, &svAcc); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal service account key")
+	}
+
+	return &svAcc, nil
+}
+
+// newServiceAccountName returns the name of a new zop service account.
+func newServiceAccountName() string {
+	return fmt.Sprintf("%s%v", serviceAccountPrefix, time.Now().Unix())
+}
+
+func newServiceAccountConfig(projectID, serviceAccountName string, roles []string) *serviceAccountConfig {
 	return &serviceAccountConfig{
 		ProjectID:          projectID,
 		ServiceAccountName: serviceAccountName,
-		Roles: []string{
-			"roles/editor",
-			"roles/container.admin",
-			"roles/resourcemanager.projectIamAdmin",
-			"roles/iam.roleAdmin",
-			"roles/secretmanager.admin",
-			"roles/servicenetworking.networksAdmin",
-			"roles/storage.admin",
-			"roles/dns.admin",
-			"roles/artifactregistry.admin",
-			"roles/pubsub.admin",
-		},
+		Roles:              roles,
 	}
 }
 
+// email returns the email of the service account described by the config.
+func (c *serviceAccountConfig) email() string {
+	return fmt.Sprintf("%s@%s.iam.gserviceaccount.com", c.ServiceAccountName, c.ProjectID)
+}
+
+// getOrCreateServiceAccount returns the zop service account of the project, creating it if the project has none,
+// and reports whether it was created. The name of an existing service account is set in the config.
+func getOrCreateServiceAccount(ctx context.Context, config *serviceAccountConfig) (*iam.ServiceAccount, bool, error) {
+	serviceAccount, err := findServiceAccount(ctx, config.ProjectID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if serviceAccount == nil {
+		serviceAccount, err = createServiceAccount(ctx, config)
+
+		return serviceAccount, err == nil, err
+	}
+
+	config.ServiceAccountName, _, _ = strings.Cut(serviceAccount.Email, "@")
+
+	return serviceAccount, false, nil
+}
+
+// findServiceAccount returns an enabled service account created by zop in the project, or nil if there is none.
+func findServiceAccount(ctx context.Context, projectID string) (*iam.ServiceAccount, error) {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create IAM client")
+	}
+
+	var found *iam.ServiceAccount
+
+	err = iamService.Projects.ServiceAccounts.List(fmt.Sprintf("projects/%s", projectID)).
+		Pages(ctx, func(resp *iam.ListServiceAccountsResponse) error {
+			for _, sa := range resp.Accounts {
+				if found == nil && isZopServiceAccount(sa) {
+					found = sa
+				}
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list service accounts")
+	}
+
+	return found, nil
+}
+
+// isZopServiceAccount reports whether the service account was created by zop and can be reused.
+func isZopServiceAccount(sa *iam.ServiceAccount) bool {
+	return !sa.Disabled && sa.Description == serviceAccountDescription &&
+		strings.HasPrefix(sa.Email, serviceAccountPrefix)
+}
+
 func createServiceAccount(ctx context.Context, config *serviceAccountConfig) (*iam.ServiceAccount, error) {
 	iamService, err := iam.NewService(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create IAM client")
 	}
 
-	serviceAccountEmail := fmt.Sprintf("%s@%s.iam.gserviceaccount.com",
-		config.ServiceAccountName, config.ProjectID)
+	serviceAccountEmail := config.email()
 
 	// Check if service account exists
 	_, err = iamService.Projects.ServiceAccounts.Get(
@@ -139,14 +297,14 @@ func createServiceAccount(ctx context.Context, config *serviceAccountConfig) (*i
 			config.ProjectID, serviceAccountEmail)).Do()
 
 	if err == nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("service account %v already exists", serviceAccountEmail))
+		return nil, errors.Wrapf(errServiceAccountExists, "service account %v", serviceAccountEmail)
 	}
 
 	request := &iam.CreateServiceAccountRequest{
 		AccountId: config.ServiceAccountName,
 		ServiceAccount: &iam.ServiceAccount{
 			DisplayName: config.ServiceAccountName,
-			Description: "Service account for ZOP",
+			Description: serviceAccountDescription,
 		},
 	}
 
@@ -159,7 +317,7 @@ func createServiceAccount(ctx context.Context, config *serviceAccountConfig) (*i
 	return serviceAccount, nil
 }
 
-func createServiceAccountKey(ctx context.Context, serviceAccount *iam.ServiceAccount) ([]byte, error) {
+func createServiceAccountKey(ctx context.Context, serviceAccount *iam.ServiceAccount) (*iam.ServiceAccountKey, error) {
 	iamService, err := iam.NewService(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create IAM client")
@@ -174,50 +332,7 @@ func createServiceAccountKey(ctx context.Context, serviceAccount *iam.ServiceAcc
 		return nil, errors.Wrap(err, "failed to create service account key")
 	}
 
-	return []byte(key.PrivateKeyData), nil
-}
-
-func assignRoles(ctx context.Context, config *serviceAccountConfig, serviceAccount *iam.ServiceAccount) error {
-	crmService, err := cloudresourcemanager.NewService(ctx)
-	if err != nil {
-		return errors.Wrap(err, "failed to create Cloud Resource Manager client")
-	}
-
-	policy, err := crmService.Projects.GetIamPolicy(config.ProjectID,
-		&cloudresourcemanager.GetIamPolicyRequest{}).Do()
-	if err != nil {
-		return errors.Wrap(err, "failed to get IAM policy")
-	}
-
-	member := fmt.Sprintf("serviceAccount:%s", serviceAccount.Email)
-
-	for _, role := range config.Roles {
-		found := false
-
-		for _, binding := range policy.Bindings {
-			if binding.Role == role {
-				binding.Members = append(binding.Members, member)
-				found = true
-
-				break
-			}
-		}
-
-		if !found {
-			policy.Bindings = append(policy.Bindings, &cloudresourcemanager.Binding{
-				Role:    role,
-				Members: []string{member},
-			})
-		}
-	}
-
-	_, err = crmService.Projects.SetIamPolicy(config.ProjectID,
-		&cloudresourcemanager.SetIamPolicyRequest{Policy: policy}).Do()
-	if err != nil {
-		return errors.Wrap(err, "failed to set IAM policy")
-	}
-
-	return nil
+	return key, nil
 }
 
 func refreshAccessToken(ctx *gofr.Context, clientID, clientSecret, refreshToken string) (*oauth2.Token, error) {
@@ -246,7 +361,10 @@ func refreshAccessToken(ctx *gofr.Context, clientID, clientSecret, refreshToken
 	return &newToken, nil
 }
 
-func fetchProjects(ctx *gofr.Context, clientID, clientSecret string, token *oauth2.Token) ([]*cloudresourcemanager.Project, error) {
+// fetchProjects returns every project the user account has access to that matches the filter,
+// reading all pages of the project list.
+func fetchProjects(ctx *gofr.Context, clientID, clientSecret string, token *oauth2.Token,
+	filter *projectFilter) ([]*cloudresourcemanager.Project, error) {
 	config := &oauth2.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
@@ -264,12 +382,41 @@ func fetchProjects(ctx *gofr.Context, clientID, clientSecret string, token *oaut
 		return nil, err
 	}
 
-	resp, err := cloudService.Projects.List().Do()
+	ancestry := func(projectID string) ([]*cloudresourcemanager.ResourceId, error) {
+		resp, er := cloudService.Projects.GetAncestry(projectID, &cloudresourcemanager.GetAncestryRequest{}).Do()
+		if er != nil {
+			return nil, errors.Wrap(er, fmt.Sprintf("failed to get the ancestry of project %s", projectID))
+		}
+
+		ids := make([]*cloudresourcemanager.ResourceId, 0, len(resp.Ancestor))
+		for _, ancestor := range resp.Ancestor {
+			ids = append(ids, ancestor.ResourceId)
+		}
+
+		return ids, nil
+	}
+
+	projects := make([]*cloudresourcemanager.Project, 0)
+
+	err = cloudService.Projects.List().Pages(ctx, func(resp *cloudresourcemanager.ListProjectsResponse) error {
+		for _, project := range resp.Projects {
+			ok, er := filter.matches(project, ancestry)
+			if er != nil {
+				return er
+			}
+
+			if ok {
+				projects = append(projects, project)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		ctx.Errorf("Failed to list projects: %v", err)
 
 		return nil, err
 	}
 
-	return resp.Projects, nil
+	return projects, nil
 }
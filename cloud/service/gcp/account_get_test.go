@@ -0,0 +1,41 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iam/v1"
+)
+
+func Test_isZopServiceAccount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sa       *iam.ServiceAccount
+		expected bool
+	}{
+		{
+			name:     "zop service account",
+			sa:       &iam.ServiceAccount{Email: "zop-dev-1700000000@proj.iam.gserviceaccount.com", Description: serviceAccountDescription},
+			expected: true,
+		},
+		{
+			name: "disabled zop service account",
+			sa: &iam.ServiceAccount{Email: "zop-dev-1700000000@proj.iam.gserviceaccount.com",
+				Description: serviceAccountDescription, Disabled: true},
+		},
+		{
+			name: "other description",
+			sa:   &iam.ServiceAccount{Email: "zop-dev-1700000000@proj.iam.gserviceaccount.com", Description: "ci"},
+		},
+		{
+			name: "other name",
+			sa:   &iam.ServiceAccount{Email: "deploy@proj.iam.gserviceaccount.com", Description: serviceAccountDescription},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isZopServiceAccount(tc.sa))
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package gcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const (
+	credTypeServiceAccount = "service_account"
+	credTypeAuthorizedUser = "authorized_user"
+	credTypeExternal       = "external_account"
+	credTypeImpersonated   = "impersonated_service_account"
+
+	serviceAccountDomain = ".iam.gserviceaccount.com"
+)
+
+var (
+	// ErrUnsupportedCredentialType is returned for gcloud credentials of a type that cannot be imported.
+	ErrUnsupportedCredentialType = errors.New("unsupported credential type")
+
+	// ErrLocalCredentialSource is returned for workload identity federation configs whose subject token only
+	// exists on the local machine, like in a file, from a command or from the metadata server. zop api cannot
+	// read it, so such configs are skipped.
+	ErrLocalCredentialSource = errors.New("the subject token of the workload identity federation config is local")
+)
+
+// credential is a gcloud credential imported into zop api as is, without creating a service account.
+type credential struct {
+	// kind describes the credential to the user.
+	kind      string
+	projectID string
+	value     any
+}
+
+// credentialHeader holds the fields identifying the type of gcloud credentials.
+type credentialHeader struct {
+	Type       string `json:"type"`
+	PrivateKey string `json:"private_key"`
+}
+
+// credentialType returns the type of the gcloud credentials. Entries of the gcloud database may have no
+// type, they are service account keys if they hold a private key and user credentials otherwise.
+func credentialType(value []byte) (string, error) {
+	var header credentialHeader
+
+	if err := json.Unmarshal(value, &header); err != nil {
+		return "", err
+	}
+
+	switch {
+	case header.Type != "":
+		return header.Type, nil
+	case header.PrivateKey != "":
+		return credTypeServiceAccount, nil
+	default:
+		return credTypeAuthorizedUser, nil
+	}
+}
+
+// newServiceAccountCredential returns a service account key, imported as is.
+func newServiceAccountCredential(value []byte) (*credential, error) {
+	var svcAcc serviceAccountCreds
+
+	if err := json.Unmarshal(value, &svcAcc); err != nil {
+		return nil, err
+	}
+
+	return &credential{kind: "service account key", projectID: svcAcc.ProjectID, value: &svcAcc}, nil
+}
+
+// credentialSource is where a workload identity federation config reads the subject token from.
+type credentialSource struct {
+	File          string          `json:"file"`
+	URL           string          `json:"url"`
+	Executable    json.RawMessage `json:"executable"`
+	Certificate   json.RawMessage `json:"certificate"`
+	EnvironmentID string          `json:"environment_id"`
+}
+
+// newExternalCredential returns a workload identity federation config. It holds no secret, zop api
+// fetches the token of the external identity provider itself, so the config is imported as is. Only configs
+// whose subject token zop api can fetch from a remote url are imported.
+func newExternalCredential(value []byte) (*credential, error) {
+	var cfg struct {
+		ImpersonationURL string            `json:"service_account_impersonation_url"`
+		CredentialSource *credentialSource `json:"credential_source"`
+	}
+
+	if err := json.Unmarshal(value, &cfg); err != nil {
+		return nil, err
+	}
+
+	if err := checkCredentialSource(cfg.CredentialSource); err != nil {
+		return nil, err
+	}
+
+	return &credential{
+		kind:      "workload identity federation config",
+		projectID: serviceAccountProject(impersonatedEmail(cfg.ImpersonationURL)),
+		value:     json.RawMessage(value),
+	}, nil
+}
+
+// checkCredentialSource returns ErrLocalCredentialSource unless the subject token is fetched from a url
+// outside of the local machine and its network.
+func checkCredentialSource(src *credentialSource) error {
+	switch {
+	case src == nil:
+		return fmt.Errorf("%w: the config has no credential_source", ErrLocalCredentialSource)
+	case src.File != "":
+		return fmt.Errorf("%w: it is read from the file %s", ErrLocalCredentialSource, src.File)
+	case len(src.Executable) != 0:
+		return fmt.Errorf("%w: it is returned by a local command", ErrLocalCredentialSource)
+	case len(src.Certificate) != 0:
+		return fmt.Errorf("%w: it is a local certificate", ErrLocalCredentialSource)
+	case src.EnvironmentID != "":
+		return fmt.Errorf("%w: it is read from the %s environment of this machine", ErrLocalCredentialSource,
+			src.EnvironmentID)
+	case src.URL == "":
+		return fmt.Errorf("%w: the credential_source has no url", ErrLocalCredentialSource)
+	case isLocalURL(src.URL):
+		return fmt.Errorf("%w: it is read from %s", ErrLocalCredentialSource, src.URL)
+	}
+
+	return nil
+}
+
+// isLocalURL reports whether the url points to the local machine or network, like a metadata server.
+// URLs that cannot be parsed are reported local as well.
+func isLocalURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return true
+	}
+
+	if u.Hostname() == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(u.Hostname())
+
+	return ip != nil && (ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsPrivate() || ip.IsUnspecified())
+}
+
+// newImpersonatedCredential returns the config of an impersonated service account without its source
+// credentials, which belong to the local user. zop api impersonates the service account with its own identity.
+func newImpersonatedCredential(value []byte) (*credential, error) {
+	var cfg map[string]json.RawMessage
+
+	if err := json.Unmarshal(value, &cfg); err != nil {
+		return nil, err
+	}
+
+	var url string
+
+	if err := json.Unmarshal(cfg["service_account_impersonation_url"], &url); err != nil {
+		return nil, fmt.Errorf("%w: %s without service_account_impersonation_url", ErrUnsupportedCredentialType, credTypeImpersonated)
+	}
+
+	delete(cfg, "source_credentials")
+
+	stripped, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &credential{
+		kind:      "impersonated service account " + impersonatedEmail(url),
+		projectID: serviceAccountProject(impersonatedEmail(url)),
+		value:     json.RawMessage(stripped),
+	}, nil
+}
+
+// impersonatedEmail returns the email of the service account in a service account impersonation url like
+// https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/<email>:generateAccessToken.
+func impersonatedEmail(url string) string {
+	_, email, ok := strings.Cut(url, "/serviceAccounts/")
+	if !ok {
+		return ""
+	}
+
+	email, _, _ = strings.Cut(email, ":")
+
+	return email
+}
+
+// serviceAccountProject returns the project of a user-managed service account from its email.
+func serviceAccountProject(email string) string {
+	_, domain, _ := strings.Cut(email, "@")
+
+	project, ok := strings.CutSuffix(domain, serviceAccountDomain)
+	if !ok {
+		return ""
+	}
+
+	return project
+}
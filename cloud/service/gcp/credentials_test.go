@@ -0,0 +1,129 @@
+package gcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/store/gcp"
+)
+
+const impersonationURL = "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/" +
+	"deploy@payments.iam.gserviceaccount.com:generateAccessToken"
+
+func externalConfig(source string) string {
+	return `{"type": "external_account",
+		"audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/ci/providers/github",
+		"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+		"token_url": "https://sts.googleapis.com/v1/token",
+		"credential_source": ` + source + `,
+		"service_account_impersonation_url": "` + impersonationURL + `"}`
+}
+
+func Test_newAccountPlan_credentialTypes(t *testing.T) {
+	external := externalConfig(`{"url": "https://token.actions.githubusercontent.com/?audience=zop",
+		"headers": {"Authorization": "bearer"}, "format": {"type": "json", "subject_token_field_name": "value"}}`)
+
+	impersonated := `{"type": "impersonated_service_account",
+		"delegates": [],
+		"service_account_impersonation_url": "` + impersonationURL + `",
+		"source_credentials": {"type": "authorized_user", "refresh_token": "secret"}}`
+
+	testCases := []struct {
+		name      string
+		value     string
+		kind      string
+		projectID string
+		posted    string
+		expErr    error
+	}{
+		{
+			name:      "service account key",
+			value:     `{"type": "service_account", "project_id": "payments", "private_key": "key"}`,
+			kind:      "service account key",
+			projectID: "payments",
+		},
+		{
+			name:      "service account key without type",
+			value:     `{"project_id": "payments", "private_key": "key"}`,
+			kind:      "service account key",
+			projectID: "payments",
+		},
+		{
+			name:      "workload identity federation",
+			value:     external,
+			kind:      "workload identity federation config",
+			projectID: "payments",
+			posted:    external,
+		},
+		{
+			name:   "workload identity federation from a file",
+			value:  externalConfig(`{"file": "/var/run/token"}`),
+			expErr: ErrLocalCredentialSource,
+		},
+		{
+			name:   "workload identity federation from a command",
+			value:  externalConfig(`{"executable": {"command": "/usr/local/bin/token", "timeout_millis": 5000}}`),
+			expErr: ErrLocalCredentialSource,
+		},
+		{
+			name:   "workload identity federation from the metadata server",
+			value:  externalConfig(`{"url": "http://169.254.169.254/metadata/identity/oauth2/token"}`),
+			expErr: ErrLocalCredentialSource,
+		},
+		{
+			name: "workload identity federation from aws",
+			value: externalConfig(`{"environment_id": "aws1",
+				"region_url": "http://169.254.169.254/latest/meta-data/placement/availability-zone"}`),
+			expErr: ErrLocalCredentialSource,
+		},
+		{
+			name:      "impersonated service account",
+			value:     impersonated,
+			kind:      "impersonated service account deploy@payments.iam.gserviceaccount.com",
+			projectID: "payments",
+			posted: `{"type": "impersonated_service_account", "delegates": [],
+				"service_account_impersonation_url": "` + impersonationURL + `"}`,
+		},
+		{
+			name:   "impersonated service account without url",
+			value:  `{"type": "impersonated_service_account"}`,
+			expErr: ErrUnsupportedCredentialType,
+		},
+		{
+			name:   "unknown type",
+			value:  `{"type": "gdch_service_account"}`,
+			expErr: ErrUnsupportedCredentialType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := newAccountPlan(&gofr.Context{}, &gcp.AccountStore{AccountID: "acc", Value: []byte(tc.value)}, nil, nil)
+
+			require.ErrorIs(t, err, tc.expErr)
+
+			if tc.expErr != nil {
+				return
+			}
+
+			require.Equal(t, tc.kind, plan.credential.kind)
+			require.Equal(t, tc.projectID, plan.credential.projectID)
+
+			if tc.posted != "" {
+				b, er := json.Marshal(plan.credential.value)
+
+				require.NoError(t, er)
+				require.JSONEq(t, tc.posted, string(b))
+			}
+		})
+	}
+}
+
+func Test_serviceAccountProject(t *testing.T) {
+	require.Equal(t, "payments", serviceAccountProject("deploy@payments.iam.gserviceaccount.com"))
+	require.Empty(t, serviceAccountProject("123-compute@developer.gserviceaccount.com"))
+	require.Empty(t, serviceAccountProject(impersonatedEmail("https://example.com/token")))
+}
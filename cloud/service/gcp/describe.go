@@ -0,0 +1,65 @@
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/service/list"
+)
+
+// GetCredentialInfo describes the credentials zop api holds for the gcp cloud account: the service account,
+// and the id and creation time of its key. It returns nil for accounts of other providers. When the creation
+// time of the key cannot be read from IAM it is left zero.
+func (*Service) GetCredentialInfo(ctx *gofr.Context, acc *list.CloudAccountResponse) (*list.CredentialInfo, error) {
+	if acc.Provider != providerName {
+		return nil, nil
+	}
+
+	raw, err := fetchCredentials(ctx, acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseCredentialInfo(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.KeyID == "" {
+		return info, nil
+	}
+
+	name := fmt.Sprintf("projects/%s/serviceAccounts/%s/keys/%s",
+		serviceAccountProject(info.ServiceAccount), info.ServiceAccount, info.KeyID)
+
+	if info.KeyCreatedAt, err = getKeyCreationTime(ctx, name); err != nil {
+		ctx.Logger.Errorf("unable to read the creation time of key %s: %v", info.KeyID, err)
+	}
+
+	return info, nil
+}
+
+// parseCredentialInfo reads the service account and the key id from the credentials of a cloud account,
+// for service account keys as well as for keyless and impersonated service accounts.
+func parseCredentialInfo(raw json.RawMessage) (*list.CredentialInfo, error) {
+	var creds struct {
+		ClientEmail      string `json:"client_email"`
+		PrivateKeyID     string `json:"private_key_id"`
+		ImpersonationURL string `json:"service_account_impersonation_url"`
+	}
+
+	if len(raw) != 0 {
+		if err := json.Unmarshal(raw, &creds); err != nil {
+			return nil, err
+		}
+	}
+
+	info := &list.CredentialInfo{ServiceAccount: creds.ClientEmail, KeyID: creds.PrivateKeyID}
+	if info.ServiceAccount == "" {
+		info.ServiceAccount = impersonatedEmail(creds.ImpersonationURL)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,42 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zop.dev/cli/zop/cloud/service/list"
+)
+
+func Test_parseCredentialInfo(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		expected *list.CredentialInfo
+	}{
+		{
+			name:     "service account key",
+			raw:      `{"type":"service_account","client_email":"zop-dev-1@proj.iam.gserviceaccount.com","private_key_id":"abc"}`,
+			expected: &list.CredentialInfo{ServiceAccount: "zop-dev-1@proj.iam.gserviceaccount.com", KeyID: "abc"},
+		},
+		{
+			name: "keyless service account",
+			raw: `{"type":"impersonated_service_account","service_account_impersonation_url":` +
+				`"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/zop-dev-1@proj.iam.gserviceaccount.com:generateAccessToken"}`,
+			expected: &list.CredentialInfo{ServiceAccount: "zop-dev-1@proj.iam.gserviceaccount.com"},
+		},
+		{
+			name:     "no credentials",
+			expected: &list.CredentialInfo{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := parseCredentialInfo([]byte(tc.raw))
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, info)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package gcp
+
+import (
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/utils"
+)
+
+const (
+	planExists  = "already exists, would be skipped (409)"
+	planNew     = "new account"
+	planSkipped = "zop-api already has the project, would be skipped"
+)
+
+// printPlan returns what an import would do for the plans without creating or posting anything:
+// whether a credential is used as-is or converted, the service account and roles that would be created
+// or reused in each project and whether zop-api already has a cloud account for the project, given by imported.
+// The plan is printed in detail as well when a table is rendered, the other formats only render the results.
+func printPlan(ctx *gofr.Context, plans []*accountPlan, imported map[string]bool) ([]provider.Result, error) {
+	printf := func(string, ...any) {}
+	if format, _ := utils.OutputFormat(ctx); format == utils.OutputTable {
+		printf = ctx.Out.Printf
+	}
+
+	status := func(projectID string) string {
+		if imported[projectID] {
+			return planExists
+		}
+
+		return planNew
+	}
+
+	results := make([]provider.Result, 0, len(plans))
+
+	printf("Dry run, nothing will be created or imported.\n")
+
+	for _, plan := range plans {
+		if plan.credential != nil {
+			printf("\n%s: %s, used as-is\n", plan.accountID, plan.credential.kind)
+			printf("  project: %s\n", plan.credential.projectID)
+			printf("    zop-api: %s\n", status(plan.credential.projectID))
+
+			results = append(results, planResult(plan.accountID, plan.credential.projectID,
+				imported[plan.credential.projectID], plan.credential.kind+", used as-is"))
+
+			continue
+		}
+
+		printf("\n%s: user account, converted to a new service account in %d project(s)\n",
+			plan.accountID, len(plan.projects))
+		printf("  role profile: %s\n", plan.roleProfile.Name)
+
+		name := newServiceAccountName()
+
+		for _, p := range plan.projects {
+			config := newServiceAccountConfig(p.ProjectId, name, plan.roleProfile.Roles)
+
+			printf("  project: %s\n", p.ProjectId)
+
+			if imported[p.ProjectId] {
+				printf("    zop-api: %s, no key would be created\n", planExists)
+
+				results = append(results, planResult(plan.accountID, p.ProjectId, true, ""))
+
+				continue
+			}
+
+			existing, err := findServiceAccount(ctx, p.ProjectId)
+			if err != nil {
+				return nil, err
+			}
+
+			serviceAccount := config.email() + " (new)"
+			if existing != nil {
+				serviceAccount = existing.Email + " (existing)"
+			}
+
+			printf("    service account: %s\n", serviceAccount)
+
+			var key string
+
+			switch {
+			case forceKeyless(ctx):
+				key = "none, keyless mode"
+			case keyCreationDisabled(ctx, p.ProjectId):
+				key = fmt.Sprintf("none, keyless mode as %s is enforced", keyCreationConstraint)
+			default:
+				key = "a new key would be created"
+			}
+
+			printf("    key: %s\n", key)
+			printf("    roles:\n")
+
+			for _, role := range config.Roles {
+				printf("      - %s\n", role)
+			}
+
+			printf("    zop-api: %s\n", status(p.ProjectId))
+
+			results = append(results, planResult(plan.accountID, p.ProjectId, false,
+				fmt.Sprintf("service account %s with role profile %s, key: %s", serviceAccount, plan.roleProfile.Name, key)))
+		}
+	}
+
+	return results, nil
+}
+
+// planResult returns the result a dry run reports for the project of an account, skipped if zop-api already
+// has the project and planned with the reason describing the import otherwise.
+func planResult(account, project string, exists bool, reason string) provider.Result {
+	if exists {
+		return provider.Result{Account: account, Project: project, Status: provider.StatusSkipped, Reason: planSkipped}
+	}
+
+	return provider.Result{Account: account, Project: project, Status: provider.StatusPlanned, Reason: reason}
+}
@@ -0,0 +1,82 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+	"gofr.dev/pkg/gofr/cmd/terminal"
+	"gofr.dev/pkg/gofr/testutil"
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/service/list"
+	"zop.dev/cli/zop/config"
+)
+
+func TestService_getImportedProjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := NewMockAccountGetter(ctrl)
+	mockGetter.EXPECT().GetAccounts(gomock.Any()).Return([]*list.CloudAccountResponse{
+		{Provider: "gcp", ProviderID: "payments"},
+		{Provider: "aws", ProviderID: "search"},
+	}, nil)
+
+	imported, err := New(nil, mockGetter, &config.Config{}).getImportedProjects(&gofr.Context{})
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"payments": true}, imported)
+}
+
+func Test_printPlan(t *testing.T) {
+	plans := []*accountPlan{
+		{accountID: "ci@payments.iam.gserviceaccount.com", credential: &credential{kind: "service account key", projectID: "payments"}},
+		{accountID: "dev@example.com", projects: []*cloudresourcemanager.Project{{ProjectId: "search"}},
+			roleProfile: &roleProfile{Name: "full", Roles: builtinRoleProfiles()["full"]}},
+		{accountID: "sa@billing.iam.gserviceaccount.com", credential: &credential{kind: "service account key", projectID: "billing"}},
+	}
+
+	var results []provider.Result
+
+	out := testutil.StdoutOutputForFunc(func() {
+		var err error
+
+		results, err = printPlan(&gofr.Context{Out: terminal.New(), Request: cmd.NewRequest([]string{""})},
+			plans, map[string]bool{"payments": true, "search": true})
+
+		require.NoError(t, err)
+	})
+
+	require.Equal(t, "Dry run, nothing will be created or imported.\n"+
+		"\nci@payments.iam.gserviceaccount.com: service account key, used as-is\n"+
+		"  project: payments\n"+
+		"    zop-api: "+planExists+"\n"+
+		"\ndev@example.com: user account, converted to a new service account in 1 project(s)\n"+
+		"  role profile: full\n"+
+		"  project: search\n"+
+		"    zop-api: "+planExists+", no key would be created\n"+
+		"\nsa@billing.iam.gserviceaccount.com: service account key, used as-is\n"+
+		"  project: billing\n"+
+		"    zop-api: "+planNew+"\n", out)
+
+	require.Equal(t, []provider.Result{
+		{Account: "ci@payments.iam.gserviceaccount.com", Project: "payments", Status: provider.StatusSkipped, Reason: planSkipped},
+		{Account: "dev@example.com", Project: "search", Status: provider.StatusSkipped, Reason: planSkipped},
+		{Account: "sa@billing.iam.gserviceaccount.com", Project: "billing", Status: provider.StatusPlanned,
+			Reason: "service account key, used as-is"},
+	}, results)
+
+	// Other formats only render the results, the detailed plan would corrupt them.
+	out = testutil.StdoutOutputForFunc(func() {
+		_, err := printPlan(&gofr.Context{Out: terminal.New(), Request: cmd.NewRequest([]string{"", "-output=json"})},
+			plans, map[string]bool{"payments": true, "search": true})
+
+		require.NoError(t, err)
+	})
+
+	require.Empty(t, out)
+}
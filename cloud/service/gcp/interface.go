@@ -1,8 +1,13 @@
 package gcp
 
 import (
+	"context"
+	"time"
+
 	"gofr.dev/pkg/gofr"
+	iam "google.golang.org/api/iam/v1"
 
+	"zop.dev/cli/zop/cloud/service/list"
 	"zop.dev/cli/zop/cloud/store/gcp"
 )
 
@@ -10,3 +15,17 @@ import (
 type AccountStore interface {
 	GetAccounts(ctx *gofr.Context) ([]gcp.AccountStore, error)
 }
+
+// AccountGetter is an interface for getting the cloud accounts already present in the zop api,
+// and for deleting them.
+type AccountGetter interface {
+	GetAccounts(ctx *gofr.Context) ([]*list.CloudAccountResponse, error)
+	DeleteAccount(ctx *gofr.Context, id int64) error
+}
+
+// KeyManager is an interface for creating, describing and deleting the keys of gcp service accounts.
+type KeyManager interface {
+	CreateKey(ctx context.Context, serviceAccount *iam.ServiceAccount) (*iam.ServiceAccountKey, error)
+	GetKeyCreationTime(ctx context.Context, name string) (time.Time, error)
+	DeleteKey(ctx context.Context, name string) error
+}
@@ -0,0 +1,232 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iam/v1"
+)
+
+const (
+	keyCreationConstraint  = "constraints/iam.disableServiceAccountKeyCreation"
+	keyCreationNotAllowed  = "Key creation is not allowed"
+	preconditionFailure    = "type.googleapis.com/google.rpc.PreconditionFailure"
+	reasonFailedPrecond    = "failedPrecondition"
+	roleTokenCreator       = "roles/iam.serviceAccountTokenCreator"
+	impersonationURLFormat = "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken"
+)
+
+var (
+	// ErrKeyCreationDisabled is returned when the organization policy of a project forbids service account keys
+	// and the service account cannot be imported in keyless mode instead.
+	ErrKeyCreationDisabled = errors.New("service account key creation is disabled by the organization policy " +
+		keyCreationConstraint)
+
+	// ErrNoAPIServiceAccount is returned when a service account is imported in keyless mode,
+	// but the service account of zop api is not set in the zop config file.
+	ErrNoAPIServiceAccount = errors.New("set apiServiceAccount in the zop config file to import service accounts in keyless mode")
+)
+
+// keylessCreds are the credentials of a service account imported in keyless mode. They hold no secret,
+// zop api impersonates the service account with its own identity.
+type keylessCreds struct {
+	Type                           string   `json:"type"`
+	ServiceAccountImpersonationURL string   `json:"service_account_impersonation_url"`
+	Delegates                      []string `json:"delegates"`
+}
+
+func newKeylessCreds(email string) *keylessCreds {
+	return &keylessCreds{
+		Type:                           credTypeImpersonated,
+		ServiceAccountImpersonationURL: fmt.Sprintf(impersonationURLFormat, email),
+		Delegates:                      []string{},
+	}
+}
+
+// forceKeyless reports whether service accounts are imported in keyless mode even where keys could be created,
+// which is enabled with -keyless.
+func forceKeyless(ctx *gofr.Context) bool {
+	keyless, _ := strconv.ParseBool(ctx.Param("keyless"))
+
+	return keyless
+}
+
+// keyCreationDisabled reports whether the effective organization policy of the project forbids service account keys.
+// A policy that cannot be read is reported as not enforced, key creation then reveals whether it is.
+func keyCreationDisabled(ctx *gofr.Context, projectID string) bool {
+	crmService, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		return false
+	}
+
+	policy, err := crmService.Projects.GetEffectiveOrgPolicy("projects/"+projectID,
+		&cloudresourcemanager.GetEffectiveOrgPolicyRequest{Constraint: keyCreationConstraint}).Do()
+	if err != nil {
+		ctx.Logger.Debugf("unable to read the organization policy of projectID %s: %v", projectID, err)
+
+		return false
+	}
+
+	return policy.BooleanPolicy != nil && policy.BooleanPolicy.Enforced
+}
+
+// isKeyCreationDisabled reports whether creating a service account key failed because of the organization policy.
+// GCP names the constraint only in the precondition failure of the error details, the message just says that
+// key creation is not allowed.
+func isKeyCreationDisabled(err error) bool {
+	var apiErr *googleapi.Error
+
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		return false
+	}
+
+	if violatesKeyCreationConstraint(apiErr.Details) {
+		return true
+	}
+
+	for _, item := range apiErr.Errors {
+		if item.Reason == reasonFailedPrecond && strings.Contains(item.Message, keyCreationNotAllowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// violatesKeyCreationConstraint reports whether the details of a GCP error hold a precondition failure
+// of the key creation constraint.
+func violatesKeyCreationConstraint(details []any) bool {
+	for _, detail := range details {
+		d, ok := detail.(map[string]any)
+		if !ok || d["@type"] != preconditionFailure {
+			continue
+		}
+
+		violations, _ := d["violations"].([]any)
+
+		for _, violation := range violations {
+			if v, ok := violation.(map[string]any); ok && v["type"] == keyCreationConstraint {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// grantTokenCreator allows the principal to impersonate the service account, retrying on concurrent policy updates.
+// It reports whether the principal was granted the role, false if it already had it.
+func grantTokenCreator(ctx context.Context, serviceAccount *iam.ServiceAccount, principal string) (bool, error) {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create IAM client")
+	}
+
+	member := "serviceAccount:" + principal
+	granted := false
+
+	err = retryOnConflict(ctx, func() error {
+		policy, er := iamService.Projects.ServiceAccounts.GetIamPolicy(serviceAccount.Name).Do()
+		if er != nil {
+			return errors.Wrap(er, "failed to get the IAM policy of the service account")
+		}
+
+		if granted = addServiceAccountMember(policy, member, roleTokenCreator); !granted {
+			return nil
+		}
+
+		_, er = iamService.Projects.ServiceAccounts.SetIamPolicy(serviceAccount.Name,
+			&iam.SetIamPolicyRequest{Policy: policy}).Do()
+		if er != nil {
+			granted = false
+
+			return errors.Wrap(er, "failed to set the IAM policy of the service account")
+		}
+
+		return nil
+	})
+
+	return granted, err
+}
+
+// revokeTokenCreator removes the grant of grantTokenCreator, retrying on concurrent policy updates.
+// name is the resource name of the service account.
+func revokeTokenCreator(ctx context.Context, name, principal string) error {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create IAM client")
+	}
+
+	member := "serviceAccount:" + principal
+
+	return retryOnConflict(ctx, func() error {
+		policy, er := iamService.Projects.ServiceAccounts.GetIamPolicy(name).Do()
+		if er != nil {
+			return errors.Wrap(er, "failed to get the IAM policy of the service account")
+		}
+
+		if !removeServiceAccountMember(policy, member, roleTokenCreator) {
+			return nil
+		}
+
+		_, er = iamService.Projects.ServiceAccounts.SetIamPolicy(name, &iam.SetIamPolicyRequest{Policy: policy}).Do()
+		if er != nil {
+			return errors.Wrap(er, "failed to set the IAM policy of the service account")
+		}
+
+		return nil
+	})
+}
+
+// addServiceAccountMember adds the member to the binding of the role in the IAM policy of a service account.
+// It reports whether the policy changed.
+func addServiceAccountMember(policy *iam.Policy, member, role string) bool {
+	for _, b := range policy.Bindings {
+		if b.Role != role || b.Condition != nil {
+			continue
+		}
+
+		if slices.Contains(b.Members, member) {
+			return false
+		}
+
+		b.Members = append(b.Members, member)
+
+		return true
+	}
+
+	policy.Bindings = append(policy.Bindings, &iam.Binding{Role: role, Members: []string{member}})
+
+	return true
+}
+
+// removeServiceAccountMember removes the member from the binding of the role in the IAM policy of a service account,
+// dropping the binding when it is left without members. It reports whether the policy changed.
+func removeServiceAccountMember(policy *iam.Policy, member, role string) bool {
+	for i, b := range policy.Bindings {
+		if b.Role != role || b.Condition != nil {
+			continue
+		}
+
+		j := slices.Index(b.Members, member)
+		if j < 0 {
+			return false
+		}
+
+		if b.Members = slices.Delete(b.Members, j, j+1); len(b.Members) == 0 {
+			policy.Bindings = slices.Delete(policy.Bindings, i, i+1)
+		}
+
+		return true
+	}
+
+	return false
+}
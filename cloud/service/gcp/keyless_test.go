@@ -0,0 +1,79 @@
+package gcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iam/v1"
+)
+
+// apiError returns the error the google api clients return for the response.
+func apiError(status int, body string) error {
+	return googleapi.CheckResponse(&http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))})
+}
+
+func Test_isKeyCreationDisabled(t *testing.T) {
+	const message = "Key creation is not allowed on this service account."
+
+	blocked := apiError(http.StatusBadRequest, `{"error": {"code": 400, "message": "`+message+`",
+		"errors": [{"message": "`+message+`", "domain": "global", "reason": "failedPrecondition"}],
+		"status": "FAILED_PRECONDITION",
+		"details": [{"@type": "type.googleapis.com/google.rpc.PreconditionFailure", "violations": [{
+			"type": "constraints/iam.disableServiceAccountKeyCreation",
+			"subject": "projects/payments?configvalue=zop-dev-1%40payments.iam.gserviceaccount.com",
+			"description": "`+message+`"}]}]}}`)
+	withoutDetails := apiError(http.StatusBadRequest, `{"error": {"code": 400, "message": "`+message+`",
+		"errors": [{"message": "`+message+`", "domain": "global", "reason": "failedPrecondition"}]}}`)
+	otherPrecondition := apiError(http.StatusBadRequest, `{"error": {"code": 400, "message": "Precondition check failed.",
+		"errors": [{"message": "Precondition check failed.", "domain": "global", "reason": "failedPrecondition"}]}}`)
+	denied := apiError(http.StatusForbidden, `{"error": {"code": 403, "message": "Permission denied",
+		"errors": [{"message": "Permission denied", "domain": "global", "reason": "forbidden"}]}}`)
+
+	require.True(t, isKeyCreationDisabled(pkgerrors.Wrap(blocked, "failed to create service account key")))
+	require.True(t, isKeyCreationDisabled(withoutDetails))
+	require.False(t, isKeyCreationDisabled(otherPrecondition))
+	require.False(t, isKeyCreationDisabled(denied))
+	require.False(t, isKeyCreationDisabled(nil))
+}
+
+func Test_addServiceAccountMember(t *testing.T) {
+	const member = "serviceAccount:zop-api@zop.iam.gserviceaccount.com"
+
+	policy := &iam.Policy{Bindings: []*iam.Binding{{Role: "roles/iam.serviceAccountUser", Members: []string{"user:dev@example.com"}}}}
+
+	require.True(t, addServiceAccountMember(policy, member, roleTokenCreator))
+	require.False(t, addServiceAccountMember(policy, member, roleTokenCreator))
+	require.Equal(t, []*iam.Binding{
+		{Role: "roles/iam.serviceAccountUser", Members: []string{"user:dev@example.com"}},
+		{Role: roleTokenCreator, Members: []string{member}},
+	}, policy.Bindings)
+}
+
+func Test_removeServiceAccountMember(t *testing.T) {
+	const member = "serviceAccount:zop-api@zop.iam.gserviceaccount.com"
+
+	policy := &iam.Policy{Bindings: []*iam.Binding{
+		{Role: roleTokenCreator, Members: []string{member}},
+		{Role: "roles/iam.serviceAccountUser", Members: []string{member}},
+	}}
+
+	require.True(t, removeServiceAccountMember(policy, member, roleTokenCreator))
+	require.False(t, removeServiceAccountMember(policy, member, roleTokenCreator))
+	require.Equal(t, []*iam.Binding{{Role: "roles/iam.serviceAccountUser", Members: []string{member}}}, policy.Bindings)
+}
+
+func Test_newKeylessCreds(t *testing.T) {
+	creds := newKeylessCreds("zop-dev-1@payments.iam.gserviceaccount.com")
+
+	require.Equal(t, &keylessCreds{
+		Type: credTypeImpersonated,
+		ServiceAccountImpersonationURL: "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/" +
+			"zop-dev-1@payments.iam.gserviceaccount.com:generateAccessToken",
+		Delegates: []string{},
+	}, creds)
+}
@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mock_interface.go -package=gcp
+//
+
+// Package gcp is a generated GoMock package.
+package gcp
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+	gofr "gofr.dev/pkg/gofr"
+	v1 "google.golang.org/api/iam/v1"
+	list "zop.dev/cli/zop/cloud/service/list"
+	gcp "zop.dev/cli/zop/cloud/store/gcp"
+)
+
+// MockAccountStore is a mock of AccountStore interface.
+type MockAccountStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountStoreMockRecorder is the mock recorder for MockAccountStore.
+type MockAccountStoreMockRecorder struct {
+	mock *MockAccountStore
+}
+
+// NewMockAccountStore creates a new mock instance.
+func NewMockAccountStore(ctrl *gomock.Controller) *MockAccountStore {
+	mock := &MockAccountStore{ctrl: ctrl}
+	mock.recorder = &MockAccountStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountStore) EXPECT() *MockAccountStoreMockRecorder {
+	return m.recorder
+}
+
+// GetAccounts mocks base method.
+func (m *MockAccountStore) GetAccounts(ctx *gofr.Context) ([]gcp.AccountStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", ctx)
+	ret0, _ := ret[0].([]gcp.AccountStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockAccountStoreMockRecorder) GetAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockAccountStore)(nil).GetAccounts), ctx)
+}
+
+// MockAccountGetter is a mock of AccountGetter interface.
+type MockAccountGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountGetterMockRecorder is the mock recorder for MockAccountGetter.
+type MockAccountGetterMockRecorder struct {
+	mock *MockAccountGetter
+}
+
+// NewMockAccountGetter creates a new mock instance.
+func NewMockAccountGetter(ctrl *gomock.Controller) *MockAccountGetter {
+	mock := &MockAccountGetter{ctrl: ctrl}
+	mock.recorder = &MockAccountGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountGetter) EXPECT() *MockAccountGetterMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccountGetter) DeleteAccount(ctx *gofr.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountGetterMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountGetter)(nil).DeleteAccount), ctx, id)
+}
+
+// GetAccounts mocks base method.
+func (m *MockAccountGetter) GetAccounts(ctx *gofr.Context) ([]*list.CloudAccountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", ctx)
+	ret0, _ := ret[0].([]*list.CloudAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockAccountGetterMockRecorder) GetAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockAccountGetter)(nil).GetAccounts), ctx)
+}
+
+// MockKeyManager is a mock of KeyManager interface.
+type MockKeyManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyManagerMockRecorder is the mock recorder for MockKeyManager.
+type MockKeyManagerMockRecorder struct {
+	mock *MockKeyManager
+}
+
+// NewMockKeyManager creates a new mock instance.
+func NewMockKeyManager(ctrl *gomock.Controller) *MockKeyManager {
+	mock := &MockKeyManager{ctrl: ctrl}
+	mock.recorder = &MockKeyManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyManager) EXPECT() *MockKeyManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateKey mocks base method.
+func (m *MockKeyManager) CreateKey(ctx context.Context, serviceAccount *v1.ServiceAccount) (*v1.ServiceAccountKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKey", ctx, serviceAccount)
+	ret0, _ := ret[0].(*v1.ServiceAccountKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockKeyManagerMockRecorder) CreateKey(ctx, serviceAccount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockKeyManager)(nil).CreateKey), ctx, serviceAccount)
+}
+
+// DeleteKey mocks base method.
+func (m *MockKeyManager) DeleteKey(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKey", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKey indicates an expected call of DeleteKey.
+func (mr *MockKeyManagerMockRecorder) DeleteKey(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKey", reflect.TypeOf((*MockKeyManager)(nil).DeleteKey), ctx, name)
+}
+
+// GetKeyCreationTime mocks base method.
+func (m *MockKeyManager) GetKeyCreationTime(ctx context.Context, name string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyCreationTime", ctx, name)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyCreationTime indicates an expected call of GetKeyCreationTime.
+func (mr *MockKeyManagerMockRecorder) GetKeyCreationTime(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyCreationTime", reflect.TypeOf((*MockKeyManager)(nil).GetKeyCreationTime), ctx, name)
+}
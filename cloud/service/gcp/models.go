@@ -23,10 +23,12 @@ type userAccountCreds struct {
 }
 
 // request is a struct for forming the request body for posting cloud accounts to zop api.
+// RoleProfile is only set for service accounts created by the import.
 type request struct {
-	Name        string `json:"name"`
-	Provider    string `json:"provider"`
-	Credentials any    `json:"credentials"`
+	Name        string       `json:"name"`
+	Provider    string       `json:"provider"`
+	Credentials any          `json:"credentials"`
+	RoleProfile *roleProfile `json:"roleProfile,omitempty"`
 }
 
 // CloudAccountResponse is a struct for storing the response from zop api for cloud accounts.
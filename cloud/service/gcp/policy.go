@@ -0,0 +1,184 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iam/v1"
+)
+
+const (
+	// maxPolicyAttempts is how often the IAM policy of a project is read, modified and written
+	// before giving up on concurrent updates.
+	maxPolicyAttempts = 5
+	policyRetryDelay  = 500 * time.Millisecond
+)
+
+// ErrRolesNotApplied is returned when the verified IAM policy misses roles of the service account.
+var ErrRolesNotApplied = errors.New("roles missing from the IAM policy")
+
+// verifyRoles reports whether the IAM policy is verified after assigning roles, which is enabled with -verify-roles.
+func verifyRoles(ctx *gofr.Context) bool {
+	verify, _ := strconv.ParseBool(ctx.Param("verify-roles"))
+
+	return verify
+}
+
+// assignRoles grants the roles of the config to the service account in the IAM policy of the project and returns
+// the roles it was not bound to before, also when verifying them fails afterwards.
+// The policy is written with the etag it was read with, so a concurrent update makes SetIamPolicy fail
+// instead of silently dropping bindings; the policy is then read again and the update retried.
+func assignRoles(ctx context.Context, config *serviceAccountConfig, serviceAccount *iam.ServiceAccount) ([]string, error) {
+	crmService, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Cloud Resource Manager client")
+	}
+
+	member := fmt.Sprintf("serviceAccount:%s", serviceAccount.Email)
+
+	var added []string
+
+	err = retryOnConflict(ctx, func() error {
+		var er error
+
+		added, er = updatePolicy(crmService, config.ProjectID, member, config.Roles)
+
+		return er
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.VerifyRoles {
+		return added, nil
+	}
+
+	policy, err := crmService.Projects.GetIamPolicy(config.ProjectID,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		return added, errors.Wrap(err, "failed to get IAM policy")
+	}
+
+	if missing := missingRoles(policy, member, config.Roles); len(missing) > 0 {
+		return added, errors.Wrap(ErrRolesNotApplied, fmt.Sprintf("%s: %v", member, missing))
+	}
+
+	return added, nil
+}
+
+// retryOnConflict runs the read-modify-write of an IAM policy in update again while it fails because the
+// policy was changed concurrently, up to maxPolicyAttempts times.
+func retryOnConflict(ctx context.Context, update func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := update()
+		if err == nil || !isPolicyConflict(err) || attempt == maxPolicyAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * policyRetryDelay):
+		}
+	}
+}
+
+// updatePolicy performs a single read-modify-write of the IAM policy of the project and returns the roles
+// the member was added to.
+func updatePolicy(crmService *cloudresourcemanager.Service, projectID, member string, roles []string) ([]string, error) {
+	policy, err := crmService.Projects.GetIamPolicy(projectID,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get IAM policy")
+	}
+
+	added := addMember(policy, member, roles)
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	_, err = crmService.Projects.SetIamPolicy(projectID,
+		&cloudresourcemanager.SetIamPolicyRequest{Policy: policy}).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set IAM policy")
+	}
+
+	return added, nil
+}
+
+// addMember adds the member to the bindings of the roles, creating missing bindings.
+// Members already bound to a role are not added again. It returns the roles the member was added to,
+// none if the policy did not change.
+func addMember(policy *cloudresourcemanager.Policy, member string, roles []string) []string {
+	var added []string
+
+	for _, role := range roles {
+		var binding *cloudresourcemanager.Binding
+
+		for _, b := range policy.Bindings {
+			if b.Role == role && b.Condition == nil {
+				binding = b
+
+				break
+			}
+		}
+
+		if binding == nil {
+			binding = &cloudresourcemanager.Binding{Role: role}
+			policy.Bindings = append(policy.Bindings, binding)
+		}
+
+		if !slices.Contains(binding.Members, member) {
+			binding.Members = append(binding.Members, member)
+			added = append(added, role)
+		}
+	}
+
+	return added
+}
+
+// missingRoles returns the roles the member is not bound to in the policy.
+func missingRoles(policy *cloudresourcemanager.Policy, member string, roles []string) []string {
+	var missing []string
+
+	for _, role := range roles {
+		bound := slices.ContainsFunc(policy.Bindings, func(b *cloudresourcemanager.Binding) bool {
+			return b.Role == role && b.Condition == nil && slices.Contains(b.Members, member)
+		})
+
+		if !bound {
+			missing = append(missing, role)
+		}
+	}
+
+	return missing
+}
+
+// isPolicyConflict reports whether SetIamPolicy failed because the policy was changed since it was read.
+func isPolicyConflict(err error) bool {
+	var apiErr *googleapi.Error
+
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.Code == http.StatusConflict {
+		return true
+	}
+
+	for _, item := range apiErr.Errors {
+		if item.Reason == "aborted" {
+			return true
+		}
+	}
+
+	return false
+}
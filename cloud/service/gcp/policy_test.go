@@ -0,0 +1,84 @@
+package gcp
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+)
+
+const testMember = "serviceAccount:zop-dev-1@proj.iam.gserviceaccount.com"
+
+func Test_addMember(t *testing.T) {
+	policy := &cloudresourcemanager.Policy{
+		Etag: "BwX1",
+		Bindings: []*cloudresourcemanager.Binding{
+			{Role: "roles/editor", Members: []string{"user:dev@example.com", testMember}},
+			{Role: "roles/viewer", Members: []string{"user:dev@example.com"},
+				Condition: &cloudresourcemanager.Expr{Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}},
+		},
+	}
+
+	added := addMember(policy, testMember, []string{"roles/editor", "roles/viewer"})
+
+	require.Equal(t, []string{"roles/viewer"}, added)
+	require.Equal(t, "BwX1", policy.Etag)
+	require.Equal(t, []*cloudresourcemanager.Binding{
+		{Role: "roles/editor", Members: []string{"user:dev@example.com", testMember}},
+		{Role: "roles/viewer", Members: []string{"user:dev@example.com"},
+			Condition: &cloudresourcemanager.Expr{Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}},
+		{Role: "roles/viewer", Members: []string{testMember}},
+	}, policy.Bindings)
+
+	require.Empty(t, addMember(policy, testMember, []string{"roles/editor", "roles/viewer"}))
+	require.Empty(t, missingRoles(policy, testMember, []string{"roles/editor", "roles/viewer"}))
+}
+
+func Test_missingRoles(t *testing.T) {
+	policy := &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			{Role: "roles/editor", Members: []string{testMember}},
+			{Role: "roles/viewer", Members: []string{"user:dev@example.com"}},
+		},
+	}
+
+	require.Equal(t, []string{"roles/viewer", "roles/container.admin"},
+		missingRoles(policy, testMember, []string{"roles/editor", "roles/viewer", "roles/container.admin"}))
+}
+
+func Test_isPolicyConflict(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "conflict",
+			err:      pkgerrors.Wrap(&googleapi.Error{Code: http.StatusConflict}, "failed to set IAM policy"),
+			expected: true,
+		},
+		{
+			name:     "aborted",
+			err:      &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "aborted"}}},
+			expected: true,
+		},
+		{
+			name: "permission denied",
+			err:  &googleapi.Error{Code: http.StatusForbidden},
+		},
+		{
+			name: "other error",
+			err:  errors.New("connection reset"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isPolicyConflict(tc.err))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+const (
+	stateDeleteRequested = "DELETE_REQUESTED"
+	labelFilterPrefix    = "label."
+)
+
+// ErrInvalidProjectFilter is returned when the value of -project-filter cannot be parsed.
+var ErrInvalidProjectFilter = errors.New("invalid project filter")
+
+// projectFilter selects the projects of a user account that service accounts are created in.
+// It is given with -project-filter as comma separated key:value terms, all of which have to match:
+//
+//	org:<id>          the project is in the organization
+//	folder:<id>       the project is in the folder or one of its sub folders
+//	label.<key>:<v>   the project has the label
+//	state:<state>     the lifecycle state of the project, DELETE_REQUESTED projects are skipped by default
+//	name:<glob>       the project id or name matches the glob, e.g. payments-*
+type projectFilter struct {
+	organization string
+	folder       string
+	labels       map[string]string
+	state        string
+	name         string
+}
+
+// ancestryFunc returns the ancestors of a project, starting with the project itself.
+type ancestryFunc func(projectID string) ([]*cloudresourcemanager.ResourceId, error)
+
+// parseProjectFilter parses the value of -project-filter, an empty value selects every project not being deleted.
+func parseProjectFilter(param string) (*projectFilter, error) {
+	filter := &projectFilter{labels: make(map[string]string)}
+
+	for _, term := range splitParam(param) {
+		key, value, ok := strings.Cut(term, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q is not a key:value term", ErrInvalidProjectFilter, term)
+		}
+
+		switch {
+		case key == "org":
+			filter.organization = value
+		case key == "folder":
+			filter.folder = value
+		case key == "state":
+			filter.state = strings.ToUpper(value)
+		case key == "name":
+			if _, err := path.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("%w: invalid name glob %q", ErrInvalidProjectFilter, value)
+			}
+
+			filter.name = value
+		case strings.HasPrefix(key, labelFilterPrefix) && len(key) > len(labelFilterPrefix):
+			filter.labels[strings.TrimPrefix(key, labelFilterPrefix)] = value
+		default:
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidProjectFilter, key)
+		}
+	}
+
+	return filter, nil
+}
+
+// matches reports whether the project is selected by the filter. The ancestry of the project is only
+// looked up when the filter has an organization or folder its direct parent does not match.
+func (f *projectFilter) matches(project *cloudresourcemanager.Project, ancestry ancestryFunc) (bool, error) {
+	if !f.matchesState(project.LifecycleState) || !f.matchesName(project) {
+		return false, nil
+	}
+
+	for key, value := range f.labels {
+		if project.Labels[key] != value {
+			return false, nil
+		}
+	}
+
+	return f.matchesAncestry(project, ancestry)
+}
+
+func (f *projectFilter) matchesState(state string) bool {
+	if f.state == "" {
+		return state != stateDeleteRequested
+	}
+
+	return state == f.state
+}
+
+func (f *projectFilter) matchesName(project *cloudresourcemanager.Project) bool {
+	if f.name == "" {
+		return true
+	}
+
+	matchID, _ := path.Match(f.name, project.ProjectId)
+	matchName, _ := path.Match(f.name, project.Name)
+
+	return matchID || matchName
+}
+
+func (f *projectFilter) matchesAncestry(project *cloudresourcemanager.Project, ancestry ancestryFunc) (bool, error) {
+	if f.organization == "" && f.folder == "" {
+		return true, nil
+	}
+
+	wanted := make(map[string]string)
+
+	if f.organization != "" {
+		wanted["organization"] = f.organization
+	}
+
+	if f.folder != "" {
+		wanted["folder"] = f.folder
+	}
+
+	if parent := project.Parent; parent != nil && wanted[parent.Type] == parent.Id {
+		delete(wanted, parent.Type)
+	}
+
+	if len(wanted) == 0 {
+		return true, nil
+	}
+
+	ancestors, err := ancestry(project.ProjectId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ancestor := range ancestors {
+		if wanted[ancestor.Type] == ancestor.Id {
+			delete(wanted, ancestor.Type)
+		}
+	}
+
+	return len(wanted) == 0, nil
+}
@@ -0,0 +1,86 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+var errAncestry = errors.New("permission denied")
+
+func Test_parseProjectFilter(t *testing.T) {
+	filter, err := parseProjectFilter("org:123, folder:456,label.env:prod,state:active,name:payments-*")
+
+	require.NoError(t, err)
+	assert.Equal(t, &projectFilter{
+		organization: "123",
+		folder:       "456",
+		labels:       map[string]string{"env": "prod"},
+		state:        "ACTIVE",
+		name:         "payments-*",
+	}, filter)
+
+	for _, param := range []string{"org", "org:", "team:payments", "label.:prod", "name:[a"} {
+		_, err = parseProjectFilter(param)
+
+		require.ErrorIs(t, err, ErrInvalidProjectFilter, param)
+	}
+}
+
+func Test_projectFilter_matches(t *testing.T) {
+	project := &cloudresourcemanager.Project{
+		ProjectId:      "payments-prod",
+		Name:           "Payments",
+		LifecycleState: "ACTIVE",
+		Labels:         map[string]string{"env": "prod"},
+		Parent:         &cloudresourcemanager.ResourceId{Type: "folder", Id: "789"},
+	}
+
+	ancestry := func(string) ([]*cloudresourcemanager.ResourceId, error) {
+		return []*cloudresourcemanager.ResourceId{
+			{Type: "project", Id: "payments-prod"},
+			{Type: "folder", Id: "789"},
+			{Type: "folder", Id: "456"},
+			{Type: "organization", Id: "123"},
+		}, nil
+	}
+
+	testCases := []struct {
+		name     string
+		filter   string
+		project  *cloudresourcemanager.Project
+		ancestry ancestryFunc
+		expected bool
+		err      error
+	}{
+		{name: "no filter", project: project, expected: true},
+		{name: "delete requested", project: &cloudresourcemanager.Project{LifecycleState: stateDeleteRequested}},
+		{name: "state", filter: "state:delete_requested",
+			project: &cloudresourcemanager.Project{LifecycleState: stateDeleteRequested}, expected: true},
+		{name: "name glob on id", filter: "name:payments-*", project: project, expected: true},
+		{name: "name glob on name", filter: "name:Pay*", project: project, expected: true},
+		{name: "name mismatch", filter: "name:search-*", project: project},
+		{name: "label", filter: "label.env:prod", project: project, expected: true},
+		{name: "label mismatch", filter: "label.env:dev", project: project},
+		{name: "direct parent", filter: "folder:789", project: project, expected: true},
+		{name: "ancestors", filter: "org:123,folder:456", project: project, ancestry: ancestry, expected: true},
+		{name: "other organization", filter: "org:999", project: project, ancestry: ancestry},
+		{name: "ancestry error", filter: "folder:456", project: project,
+			ancestry: func(string) ([]*cloudresourcemanager.ResourceId, error) { return nil, errAncestry }, err: errAncestry},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := parseProjectFilter(tc.filter)
+			require.NoError(t, err)
+
+			ok, err := filter.matches(tc.project, tc.ancestry)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, ok)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package gcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/utils"
+)
+
+const (
+	defaultParallelism = 4
+	progressTitle      = "Importing gcloud accounts"
+
+	stepCreateServiceAccount = "creating service account"
+	stepCreateKey            = "creating key"
+	stepGrantImpersonation   = "allowing zop-api to impersonate (keyless)"
+	stepBindRoles            = "binding roles"
+	stepPost                 = "posting to zop-api"
+)
+
+var (
+	// ErrInvalidParallelism is returned when the -parallel flag is not a positive number.
+	ErrInvalidParallelism = errors.New("invalid -parallel, expected a positive number")
+
+	errAlreadyImported = errors.New("zop api already has credentials for the project")
+	errAlreadyPlanned  = errors.New("the project is already planned for import")
+)
+
+// importTask is the import of a single credential into zop api. For a user account it is the
+// service account key created in one of its projects, for other accounts their credential as is.
+type importTask struct {
+	plan    *accountPlan
+	project string
+}
+
+// name returns the name of the task shown in the progress view.
+func (t *importTask) name() string {
+	if t.project == "" {
+		return t.plan.accountID
+	}
+
+	return fmt.Sprintf("%s / %s", t.plan.accountID, t.project)
+}
+
+// newImportTasks returns the tasks of the plans, the projects in imported are skipped and returned as skipped results.
+// Each project gets a single task, so that no two tasks create service accounts in the same project at the same time,
+// for example when a user account is found both in the gcloud credentials and in the application default credentials.
+func newImportTasks(ctx *gofr.Context, plans []*accountPlan, imported map[string]bool) ([]*importTask, []provider.Result) {
+	var (
+		tasks   = make([]*importTask, 0, len(plans))
+		skipped []provider.Result
+		planned = make(map[string]string)
+	)
+
+	for _, plan := range plans {
+		if plan.credential != nil {
+			tasks = append(tasks, &importTask{plan: plan})
+
+			continue
+		}
+
+		for _, project := range plan.projects {
+			if imported[project.ProjectId] {
+				ctx.Logger.Infof("zop api already has credentials for projectID %s, no new key is created", project.ProjectId)
+
+				skipped = append(skipped, provider.Skipped(plan.accountID, project.ProjectId, errAlreadyImported))
+
+				continue
+			}
+
+			if account, ok := planned[project.ProjectId]; ok {
+				skipped = append(skipped, provider.Skipped(plan.accountID, project.ProjectId,
+					fmt.Errorf("%w by %s", errAlreadyPlanned, account)))
+
+				continue
+			}
+
+			planned[project.ProjectId] = plan.accountID
+
+			tasks = append(tasks, &importTask{plan: plan, project: project.ProjectId})
+		}
+	}
+
+	return tasks, skipped
+}
+
+// getParallelism returns the number of tasks run at the same time, given with -parallel.
+func getParallelism(ctx *gofr.Context) (int, error) {
+	param := ctx.Param("parallel")
+	if param == "" {
+		return defaultParallelism, nil
+	}
+
+	n, err := strconv.Atoi(param)
+	if err != nil || n <= 0 {
+		return 0, ErrInvalidParallelism
+	}
+
+	return n, nil
+}
+
+// runImportTasks runs the tasks with at most parallel of them at the same time, showing their progress when
+// a table is rendered to a terminal, and returns the result of every task in the order of the tasks. A failed
+// task does not stop the others, the resources of failed tasks that could not be cleaned up are printed.
+// apiServiceAccount is allowed to impersonate the service accounts imported in keyless mode.
+func runImportTasks(ctx *gofr.Context, tasks []*importTask, parallel int, apiServiceAccount string) []provider.Result {
+	names := make([]string, len(tasks))
+	for i, task := range tasks {
+		names[i] = task.name()
+	}
+
+	var progress *utils.Progress
+	if utils.Interactive(ctx) {
+		progress = utils.NewProgress(progressTitle, names)
+	}
+
+	progress.Start()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		orphaned []string
+		results  = make([]provider.Result, len(tasks))
+		queue    = make(chan int)
+	)
+
+	for range min(parallel, len(tasks)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				result, left := runImportTask(ctx, tasks[i], apiServiceAccount, func(step string) { progress.Update(i, step) })
+				progress.Done(i, string(result.Status), reasonOf(result))
+
+				results[i] = result
+
+				mu.Lock()
+				orphaned = append(orphaned, left...)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range tasks {
+		queue <- i
+	}
+
+	close(queue)
+	wg.Wait()
+	progress.Stop()
+
+	printOrphaned(ctx, orphaned)
+
+	return results
+}
+
+// reasonOf returns the reason a task failed as the error shown in the progress view.
+func reasonOf(result provider.Result) error {
+	if result.Status != provider.StatusFailed {
+		return nil
+	}
+
+	return errors.New(result.Reason) //nolint:err113 //only used to display the reason
+}
+
+// runImportTask provisions the service account key of the task if needed and posts it to zop api.
+// If the task fails the resources it created are removed again, the ones that could not be are returned.
+func runImportTask(ctx *gofr.Context, task *importTask, apiServiceAccount string,
+	progress func(step string)) (provider.Result, []string) {
+	if task.project == "" {
+		progress(stepPost)
+
+		return postAccount(ctx, task.plan, "", task.plan.credential.value), nil
+	}
+
+	config := newServiceAccountConfig(task.project, newServiceAccountName(), task.plan.roleProfile.Roles)
+	config.VerifyRoles = verifyRoles(ctx)
+	config.Keyless = forceKeyless(ctx)
+	config.APIServiceAccount = apiServiceAccount
+
+	var undo rollback
+
+	svAcc, err := provisionServiceAccount(ctx, config, &undo, progress)
+	if err != nil {
+		ctx.Logger.Errorf("Failed to provision service account for projectID %s : %v", task.project, err)
+
+		return provider.Failed(task.plan.accountID, task.project, err), undo.run(ctx)
+	}
+
+	progress(stepPost)
+
+	result := postAccount(ctx, task.plan, task.project, svAcc)
+	if result.Status == provider.StatusFailed {
+		ctx.Logger.Errorf("Failed to import service account for projectID %s : %s", task.project, result.Reason)
+
+		return result, undo.run(ctx)
+	}
+
+	return result, nil
+}
+
+// postAccount posts the credentials of the plan to zop api and returns the result for the project.
+// With -update the credentials of an account zop api already has are replaced.
+func postAccount(ctx *gofr.Context, plan *accountPlan, project string, creds any) provider.Result {
+	body, err := json.Marshal(&request{
+		Name:        plan.accountID,
+		Provider:    providerName,
+		Credentials: creds,
+		RoleProfile: plan.roleProfile,
+	})
+	if err != nil {
+		return provider.Failed(plan.accountID, project, err)
+	}
+
+	providerID := project
+	if providerID == "" && plan.credential != nil {
+		providerID = plan.credential.projectID
+	}
+
+	return provider.Post(ctx, providerName, &provider.Submission{
+		Account:     plan.accountID,
+		Project:     project,
+		ProviderID:  providerID,
+		Body:        body,
+		Credentials: creds,
+	})
+}
@@ -0,0 +1,104 @@
+package gcp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/service"
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+)
+
+func Test_newImportTasks(t *testing.T) {
+	mockCont, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Container: mockCont}
+
+	saPlan := &accountPlan{accountID: "ci@payments.iam.gserviceaccount.com", credential: &credential{}}
+	userPlan := &accountPlan{accountID: "dev@example.com", projects: []*cloudresourcemanager.Project{
+		{ProjectId: "payments"}, {ProjectId: "search"},
+	}}
+
+	adcPlan := &accountPlan{accountID: "application-default", projects: []*cloudresourcemanager.Project{
+		{ProjectId: "search"}, {ProjectId: "billing"},
+	}}
+
+	tasks, skipped := newImportTasks(ctx, []*accountPlan{saPlan, userPlan, adcPlan}, map[string]bool{"payments": true})
+
+	require.Equal(t, []*importTask{{plan: saPlan}, {plan: userPlan, project: "search"},
+		{plan: adcPlan, project: "billing"}}, tasks)
+	require.Equal(t, []provider.Result{
+		provider.Skipped("dev@example.com", "payments", errAlreadyImported),
+		{Account: "application-default", Project: "search", Status: provider.StatusSkipped,
+			Reason: "the project is already planned for import by dev@example.com"},
+	}, skipped)
+	require.Equal(t, "ci@payments.iam.gserviceaccount.com", tasks[0].name())
+	require.Equal(t, "dev@example.com / search", tasks[1].name())
+}
+
+func Test_getParallelism(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected int
+		err      error
+	}{
+		{args: []string{""}, expected: defaultParallelism},
+		{args: []string{"", "-parallel=8"}, expected: 8},
+		{args: []string{"", "-parallel=0"}, err: ErrInvalidParallelism},
+		{args: []string{"", "-parallel=many"}, err: ErrInvalidParallelism},
+	}
+
+	for _, tc := range testCases {
+		n, err := getParallelism(&gofr.Context{Request: cmd.NewRequest(tc.args)})
+
+		require.ErrorIs(t, err, tc.err)
+		require.Equal(t, tc.expected, n)
+	}
+}
+
+func Test_runImportTask_serviceAccount(t *testing.T) {
+	mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+		return service.NewMockHTTP(ctrl)
+	})
+	mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
+	ctx := &gofr.Context{Container: mockCont, Request: cmd.NewRequest([]string{""})}
+
+	task := &importTask{plan: &accountPlan{accountID: "ci@payments.iam.gserviceaccount.com",
+		credential: &credential{value: &serviceAccountCreds{Type: credTypeServiceAccount, ProjectID: "payments"}}}}
+
+	testCases := []struct {
+		name     string
+		status   int
+		expected provider.Result
+	}{
+		{name: "created", status: http.StatusCreated,
+			expected: provider.Result{Account: task.plan.accountID, Status: provider.StatusCreated}},
+		{name: "already imported", status: http.StatusConflict,
+			expected: provider.Result{Account: task.plan.accountID, Status: provider.StatusExists}},
+		{name: "api error", status: http.StatusInternalServerError,
+			expected: provider.Result{Account: task.plan.accountID, Status: provider.StatusFailed,
+				Reason: "error from api service: could not connect to the zop-api service, status code: 500"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mocks.HTTPService.EXPECT().PostWithHeaders(ctx, "cloud-accounts", nil, gomock.Any(), gomock.Any()).
+				Return(&http.Response{StatusCode: tc.status, Body: io.NopCloser(&bytes.Buffer{})}, nil)
+
+			var steps []string
+
+			result, orphaned := runImportTask(ctx, task, "", func(step string) { steps = append(steps, step) })
+
+			require.Equal(t, tc.expected, result)
+			require.Empty(t, orphaned)
+			require.Equal(t, []string{stepPost}, steps)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/iam/v1"
+
+	"zop.dev/cli/zop/cloud/service/list"
+)
+
+var (
+	// ErrPurgeUnsupported is returned when -purge is used for a cloud account that is not a gcp account.
+	ErrPurgeUnsupported = errors.New("-purge is only supported for gcp cloud accounts")
+
+	// ErrNotZopServiceAccount is returned when -purge is used for a cloud account whose service account
+	// was not created by zop, such service accounts are never deleted.
+	ErrNotZopServiceAccount = errors.New("the service account was not created by zop and is not purged")
+
+	// ErrNoStoredRoleProfile is returned when -purge is used for a cloud account zop api holds no role profile for,
+	// the roles its service account was granted are unknown so none of them are removed.
+	ErrNoStoredRoleProfile = errors.New("zop api holds no role profile for the cloud account, it is not purged")
+)
+
+// RemoveAccount deletes the cloud account from zop api. With purge the zop service account of a gcp account is
+// deleted as well: its bindings of the roles of its role profile in the IAM policy of the project, the grant
+// allowing zop api to impersonate it in keyless mode, its keys and the service account itself, exactly what
+// the import created. Only service accounts created by zop are purged.
+func (s *Service) RemoveAccount(ctx *gofr.Context, acc *list.CloudAccountResponse, purge bool) error {
+	var target *purgeTarget
+
+	if purge {
+		if acc.Provider != providerName {
+			return ErrPurgeUnsupported
+		}
+
+		var err error
+
+		if target, err = getPurgeTarget(ctx, acc.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.accountGetter.DeleteAccount(ctx, acc.ID); err != nil {
+		return err
+	}
+
+	if !purge {
+		return nil
+	}
+
+	if err := purgeServiceAccount(ctx, target, s.apiServiceAccount); err != nil {
+		return errors.Wrapf(err, "cloud account removed, but service account %s could not be purged, delete it manually",
+			target.email)
+	}
+
+	ctx.Out.Printf("Deleted service account %s, its keys and its IAM bindings\n", target.email)
+
+	return nil
+}
+
+// purgeTarget is the zop service account behind a cloud account and what the import granted it.
+type purgeTarget struct {
+	email   string
+	roles   []string
+	keyless bool
+}
+
+// getPurgeTarget returns the zop service account behind the credentials of the cloud account and the roles of
+// the role profile it was imported with, for service account keys as well as for service accounts imported in
+// keyless mode.
+func getPurgeTarget(ctx *gofr.Context, id int64) (*purgeTarget, error) {
+	account, err := fetchAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseCredentialInfo(account.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	email := info.ServiceAccount
+
+	if !strings.HasPrefix(email, serviceAccountPrefix) || serviceAccountProject(email) == "" {
+		return nil, ErrNotZopServiceAccount
+	}
+
+	if account.RoleProfile == nil {
+		return nil, ErrNoStoredRoleProfile
+	}
+
+	return &purgeTarget{email: email, roles: account.RoleProfile.Roles, keyless: info.KeyID == ""}, nil
+}
+
+// purgeServiceAccount removes the service account from the bindings of its roles in the IAM policy of its project,
+// revokes the permission of apiServiceAccount to impersonate it in keyless mode and deletes its keys and the
+// service account.
+func purgeServiceAccount(ctx context.Context, target *purgeTarget, apiServiceAccount string) error {
+	projectID := serviceAccountProject(target.email)
+	name := fmt.Sprintf("projects/%s/serviceAccounts/%s", projectID, target.email)
+
+	if err := removeRoles(ctx, projectID, "serviceAccount:"+target.email, target.roles); err != nil {
+		return err
+	}
+
+	if target.keyless && apiServiceAccount != "" {
+		if err := revokeTokenCreator(ctx, name, apiServiceAccount); err != nil {
+			return err
+		}
+	}
+
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create IAM client")
+	}
+
+	keys, err := iamService.Projects.ServiceAccounts.Keys.List(name).KeyTypes("USER_MANAGED").Do()
+	if err != nil {
+		return errors.Wrap(err, "failed to list service account keys")
+	}
+
+	for _, key := range keys.Keys {
+		if err = deleteServiceAccountKey(ctx, key.Name); err != nil {
+			return err
+		}
+	}
+
+	return deleteServiceAccount(ctx, name)
+}
+
+// removeRoles removes the member from the bindings of the roles in the IAM policy of the project.
+func removeRoles(ctx context.Context, projectID, member string, roles []string) error {
+	crmService, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create Cloud Resource Manager client")
+	}
+
+	return retryOnConflict(ctx, func() error {
+		policy, er := crmService.Projects.GetIamPolicy(projectID, &cloudresourcemanager.GetIamPolicyRequest{}).Do()
+		if er != nil {
+			return errors.Wrap(er, "failed to get IAM policy")
+		}
+
+		if !removeMember(policy, member, roles) {
+			return nil
+		}
+
+		_, er = crmService.Projects.SetIamPolicy(projectID, &cloudresourcemanager.SetIamPolicyRequest{Policy: policy}).Do()
+		if er != nil {
+			return errors.Wrap(er, "failed to set IAM policy")
+		}
+
+		return nil
+	})
+}
+
+// removeMember removes the member from the bindings of the roles, the ones addMember adds it to, dropping
+// bindings left without members. Bindings of other roles are kept. It reports whether the policy changed.
+func removeMember(policy *cloudresourcemanager.Policy, member string, roles []string) bool {
+	changed := false
+	bindings := policy.Bindings[:0]
+
+	for _, b := range policy.Bindings {
+		if b.Condition != nil || !slices.Contains(roles, b.Role) {
+			bindings = append(bindings, b)
+
+			continue
+		}
+
+		if i := slices.Index(b.Members, member); i >= 0 {
+			b.Members = slices.Delete(b.Members, i, i+1)
+			changed = true
+		}
+
+		if len(b.Members) != 0 {
+			bindings = append(bindings, b)
+		}
+	}
+
+	policy.Bindings = bindings
+
+	return changed
+}
@@ -0,0 +1,94 @@
+package gcp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/service"
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+)
+
+func Test_removeMember(t *testing.T) {
+	member := "serviceAccount:zop-dev-1@proj.iam.gserviceaccount.com"
+	roles := []string{"roles/viewer", "roles/container.admin"}
+
+	policy := &cloudresourcemanager.Policy{Bindings: []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:dev@example.com", member}},
+		{Role: "roles/container.admin", Members: []string{member}},
+		{Role: "roles/owner", Members: []string{"user:dev@example.com", member}},
+	}}
+
+	assert.True(t, removeMember(policy, member, roles))
+	assert.Equal(t, []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:dev@example.com"}},
+		{Role: "roles/owner", Members: []string{"user:dev@example.com", member}},
+	}, policy.Bindings)
+
+	assert.False(t, removeMember(policy, member, roles))
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBufferString(body))}
+}
+
+func Test_getPurgeTarget(t *testing.T) {
+	const email = "zop-dev-1@payments.iam.gserviceaccount.com"
+
+	keyless := `{"data": {"credentials": {"type": "impersonated_service_account", "service_account_impersonation_url": ` +
+		`"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/` + email + `:generateAccessToken"}, ` +
+		roleProfileJSON + `}}`
+
+	testCases := []struct {
+		name     string
+		resp     *http.Response
+		expected *purgeTarget
+		expErr   error
+	}{
+		{
+			name:     "service account key",
+			resp:     storedAccountResponse("old"),
+			expected: &purgeTarget{email: email, roles: []string{"roles/container.developer"}},
+		},
+		{
+			name:     "keyless service account",
+			resp:     response(http.StatusOK, keyless),
+			expected: &purgeTarget{email: email, roles: []string{"roles/container.developer"}, keyless: true},
+		},
+		{
+			name:   "no role profile",
+			resp:   response(http.StatusOK, `{"data": {"credentials": {"client_email": "`+email+`", "private_key_id": "k"}}}`),
+			expErr: ErrNoStoredRoleProfile,
+		},
+		{
+			name: "not a zop service account",
+			resp: response(http.StatusOK,
+				`{"data": {"credentials": {"client_email": "ci@payments.iam.gserviceaccount.com", "private_key_id": "k"}}}`),
+			expErr: ErrNotZopServiceAccount,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+				return service.NewMockHTTP(ctrl)
+			})
+			mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
+
+			mocks.HTTPService.EXPECT().Get(gomock.Any(), "cloud-accounts/1", nil).Return(tc.resp, nil)
+
+			target, err := getPurgeTarget(&gofr.Context{Container: mockCont}, 1)
+
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expected, target)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	roleProfileFull       = "full"
+	roleProfileMinimalGKE = "minimal-gke"
+)
+
+var (
+	// ErrUnknownRoleProfile is returned when the role profile given with -role-profile is neither built-in
+	// nor defined in the zop config file.
+	ErrUnknownRoleProfile = errors.New("unknown role profile")
+
+	// ErrEmptyRoleProfile is returned when the selected role profile has no roles.
+	ErrEmptyRoleProfile = errors.New("role profile has no roles")
+)
+
+// roleProfile is a named set of roles granted to the service accounts created for user accounts.
+// It is sent to zop api along with the credentials so the platform knows what the account can do.
+type roleProfile struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+// builtinRoleProfiles returns the role profiles available without any configuration.
+// full grants everything zop needs to provision infrastructure in a project, minimal-gke
+// only what is needed to deploy applications to existing GKE clusters.
+func builtinRoleProfiles() map[string][]string {
+	return map[string][]string{
+		roleProfileFull: {
+			"roles/editor",
+			"roles/container.admin",
+			"roles/resourcemanager.projectIamAdmin",
+			"roles/iam.roleAdmin",
+			"roles/secretmanager.admin",
+			"roles/servicenetworking.networksAdmin",
+			"roles/storage.admin",
+			"roles/dns.admin",
+			"roles/artifactregistry.admin",
+			"roles/pubsub.admin",
+		},
+		roleProfileMinimalGKE: {
+			"roles/container.developer",
+			"roles/artifactregistry.writer",
+			"roles/secretmanager.secretAccessor",
+			"roles/storage.objectViewer",
+		},
+	}
+}
+
+// getRoleProfile returns the role profile of the given name, the configured default profile
+// or full when no name is given.
+func (s *Service) getRoleProfile(name string) (*roleProfile, error) {
+	if name == "" {
+		name = s.defaultRoleProfile
+	}
+
+	roles, ok := s.roleProfiles[name]
+	if !ok {
+		names := make([]string, 0, len(s.roleProfiles))
+		for n := range s.roleProfiles {
+			names = append(names, n)
+		}
+
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("%w %q, available profiles: %s", ErrUnknownRoleProfile, name, strings.Join(names, ", "))
+	}
+
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyRoleProfile, name)
+	}
+
+	return &roleProfile{Name: name, Roles: roles}, nil
+}
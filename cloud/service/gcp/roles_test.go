@@ -0,0 +1,65 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zop.dev/cli/zop/config"
+)
+
+func TestService_getRoleProfile(t *testing.T) {
+	custom := []string{"roles/container.developer", "roles/logging.viewer"}
+
+	testCases := []struct {
+		name     string
+		cfg      *config.Config
+		profile  string
+		expected *roleProfile
+		expErr   error
+	}{
+		{
+			name:     "default is full",
+			cfg:      &config.Config{},
+			expected: &roleProfile{Name: "full", Roles: builtinRoleProfiles()["full"]},
+		},
+		{
+			name:     "built-in profile",
+			cfg:      &config.Config{},
+			profile:  "minimal-gke",
+			expected: &roleProfile{Name: "minimal-gke", Roles: builtinRoleProfiles()["minimal-gke"]},
+		},
+		{
+			name:     "configured default profile",
+			cfg:      &config.Config{DefaultRoleProfile: "custom", RoleProfiles: map[string][]string{"custom": custom}},
+			expected: &roleProfile{Name: "custom", Roles: custom},
+		},
+		{
+			name:     "configured profile overrides built-in",
+			cfg:      &config.Config{RoleProfiles: map[string][]string{"full": custom}},
+			profile:  "full",
+			expected: &roleProfile{Name: "full", Roles: custom},
+		},
+		{
+			name:    "custom profile not configured",
+			cfg:     &config.Config{},
+			profile: "custom",
+			expErr:  ErrUnknownRoleProfile,
+		},
+		{
+			name:    "empty profile",
+			cfg:     &config.Config{RoleProfiles: map[string][]string{"custom": {}}},
+			profile: "custom",
+			expErr:  ErrEmptyRoleProfile,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile, err := New(nil, nil, tc.cfg).getRoleProfile(tc.profile)
+
+			require.ErrorIs(t, err, tc.expErr)
+			require.Equal(t, tc.expected, profile)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/iam/v1"
+
+	"zop.dev/cli/zop/utils"
+)
+
+// rollback holds the undo steps of the resources created while provisioning a project,
+// so that a failed provisioning does not leave unused service accounts, keys or role bindings behind.
+type rollback struct {
+	steps []rollbackStep
+}
+
+type rollbackStep struct {
+	resource string
+	undo     func() error
+}
+
+// add registers the undo step of a created resource.
+func (r *rollback) add(resource string, undo func() error) {
+	r.steps = append(r.steps, rollbackStep{resource: resource, undo: undo})
+}
+
+// run undoes the registered steps in reverse order and returns the resources that could not be removed.
+func (r *rollback) run(ctx *gofr.Context) []string {
+	var orphaned []string
+
+	for i := len(r.steps) - 1; i >= 0; i-- {
+		step := r.steps[i]
+
+		if err := step.undo(); err != nil {
+			ctx.Logger.Errorf("Failed to clean up %s: %v", step.resource, err)
+
+			orphaned = append(orphaned, step.resource)
+
+			continue
+		}
+
+		ctx.Logger.Infof("Cleaned up %s", step.resource)
+	}
+
+	r.steps = nil
+
+	return orphaned
+}
+
+// printOrphaned warns about the resources a failed provisioning left behind, they have to be deleted manually.
+func printOrphaned(ctx *gofr.Context, orphaned []string) {
+	if len(orphaned) == 0 {
+		return
+	}
+
+	w := utils.Messages(ctx)
+
+	fmt.Fprintln(w, "The following resources could not be cleaned up and have to be deleted manually:")
+
+	for _, resource := range orphaned {
+		fmt.Fprintf(w, "  %s\n", resource)
+	}
+}
+
+func deleteServiceAccount(ctx context.Context, name string) error {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create IAM client")
+	}
+
+	if _, err = iamService.Projects.ServiceAccounts.Delete(name).Do(); err != nil {
+		return errors.Wrap(err, "failed to delete service account")
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+	"gofr.dev/pkg/gofr/cmd/terminal"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+var errDelete = errors.New("permission denied")
+
+func Test_rollback_run(t *testing.T) {
+	ctx := &gofr.Context{Container: &container.Container{Logger: logging.NewMockLogger(logging.INFO)}}
+
+	var (
+		undone []string
+		undo   rollback
+	)
+
+	undo.add("service account sa@proj.iam.gserviceaccount.com", func() error {
+		undone = append(undone, "service account")
+
+		return nil
+	})
+	undo.add("service account key key-1", func() error {
+		undone = append(undone, "key")
+
+		return errDelete
+	})
+
+	orphaned := undo.run(ctx)
+
+	require.Equal(t, []string{"key", "service account"}, undone)
+	require.Equal(t, []string{"service account key key-1"}, orphaned)
+	require.Empty(t, undo.run(ctx))
+}
+
+func Test_printOrphaned(t *testing.T) {
+	// the tests do not run in a terminal, so the warning is printed to stderr
+	out := testutil.StderrOutputForFunc(func() {
+		ctx := &gofr.Context{Out: terminal.New(), Request: cmd.NewRequest([]string{""})}
+
+		printOrphaned(ctx, nil)
+		printOrphaned(ctx, []string{"service account key key-1"})
+	})
+
+	require.Equal(t, "The following resources could not be cleaned up and have to be deleted manually:\n"+
+		"  service account key key-1\n", out)
+}
@@ -0,0 +1,284 @@
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/iam/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/service/list"
+	"zop.dev/cli/zop/utils"
+)
+
+const hoursPerDay = 24
+
+var (
+	// ErrNoServiceAccountKey is returned when zop api does not hold a service account key for a gcp cloud account,
+	// for example for accounts imported in keyless mode or with other credential types. Such accounts are skipped.
+	ErrNoServiceAccountKey = errors.New("cloud account has no service account key")
+
+	// ErrKeyNotAccepted is returned when zop api does not return the new key after the credentials were updated.
+	ErrKeyNotAccepted = errors.New("zop api did not accept the new key")
+)
+
+// RotateKeys rotates the service account keys of the gcp cloud accounts in zop api, or of the account with
+// the given id if it is not zero. Keys younger than minAge are left untouched.
+// For every account a new key is created on the same service account and pushed to zop api. Once zop api
+// returns the new key, the old key is deleted. If zop api does not accept the new key, the new key is
+// deleted instead and the old one keeps working. It returns the number of rotated keys.
+func (s *Service) RotateKeys(ctx *gofr.Context, id int64, minAge time.Duration) (int, error) {
+	accounts, err := s.accountGetter.GetAccounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		rotated int
+		errs    []error
+	)
+
+	for _, acc := range accounts {
+		if acc.Provider != providerName || (id != 0 && acc.ID != id) {
+			continue
+		}
+
+		done, er := s.rotateKey(ctx, acc, minAge)
+		if errors.Is(er, ErrNoServiceAccountKey) {
+			ctx.Out.Printf("%s: no service account key, not rotated\n", acc.Name)
+
+			continue
+		}
+
+		if er != nil {
+			ctx.Logger.Errorf("unable to rotate the key of cloud account %s: %v", acc.Name, er)
+
+			errs = append(errs, errors.Wrap(er, acc.Name))
+
+			continue
+		}
+
+		if done {
+			rotated++
+		}
+	}
+
+	return rotated, stderrors.Join(errs...)
+}
+
+// rotateKey rotates the key of a single cloud account. It reports false if the key is younger than minAge.
+func (s *Service) rotateKey(ctx *gofr.Context, acc *list.CloudAccountResponse, minAge time.Duration) (bool, error) {
+	current, profile, err := getAccountCredentials(ctx, acc.ID)
+	if err != nil {
+		return false, err
+	}
+
+	serviceAccount := &iam.ServiceAccount{
+		Name:  fmt.Sprintf("projects/%s/serviceAccounts/%s", current.ProjectID, current.ClientEmail),
+		Email: current.ClientEmail,
+	}
+
+	oldKey := fmt.Sprintf("%s/keys/%s", serviceAccount.Name, current.PrivateKeyID)
+
+	if minAge > 0 {
+		created, er := s.keys.GetKeyCreationTime(ctx, oldKey)
+		if er != nil {
+			return false, er
+		}
+
+		if age := time.Since(created); age < minAge {
+			ctx.Out.Printf("%s: key %s is %d days old, not rotated\n", acc.Name, current.PrivateKeyID, int(age.Hours()/hoursPerDay))
+
+			return false, nil
+		}
+	}
+
+	key, err := s.keys.CreateKey(ctx, serviceAccount)
+	if err != nil {
+		return false, err
+	}
+
+	decodedKey, err := base64.StdEncoding.DecodeString(key.PrivateKeyData)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to decode service account key")
+	}
+
+	var newCreds serviceAccountCreds
+
+	if err = json.Unmarshal(decodedKey, &newCreds); err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal service account key")
+	}
+
+	if err = updateAccountCredentials(ctx, acc, &newCreds, profile); err != nil {
+		if er := s.keys.DeleteKey(ctx, key.Name); er != nil {
+			ctx.Logger.Errorf("unable to delete the unused key %s, delete it manually: %v", key.Name, er)
+		}
+
+		return false, err
+	}
+
+	if err = s.keys.DeleteKey(ctx, oldKey); err != nil {
+		return false, errors.Wrapf(err, "new key %s is in use, but old key %s could not be deleted",
+			newCreds.PrivateKeyID, current.PrivateKeyID)
+	}
+
+	ctx.Out.Printf("%s: rotated key %s to %s\n", acc.Name, current.PrivateKeyID, newCreds.PrivateKeyID)
+
+	return true, nil
+}
+
+// getAccountCredentials returns the service account key and the role profile zop api holds for the cloud account.
+func getAccountCredentials(ctx *gofr.Context, id int64) (*serviceAccountCreds, *roleProfile, error) {
+	account, err := fetchAccount(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var creds serviceAccountCreds
+
+	if len(account.Credentials) == 0 || json.Unmarshal(account.Credentials, &creds) != nil ||
+		creds.Type != credTypeServiceAccount || creds.ClientEmail == "" || creds.PrivateKeyID == "" {
+		return nil, nil, ErrNoServiceAccountKey
+	}
+
+	return &creds, account.RoleProfile, nil
+}
+
+// fetchCredentials returns the credentials zop api holds for the cloud account.
+func fetchCredentials(ctx *gofr.Context, id int64) (json.RawMessage, error) {
+	account, err := fetchAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Credentials, nil
+}
+
+// storedAccount is what zop api holds for a gcp cloud account.
+type storedAccount struct {
+	Credentials json.RawMessage `json:"credentials"`
+	RoleProfile *roleProfile    `json:"roleProfile"`
+}
+
+// fetchAccount returns the credentials and the role profile zop api holds for the cloud account.
+func fetchAccount(ctx *gofr.Context, id int64) (*storedAccount, error) {
+	resp, err := ctx.GetHTTPService(provider.ZopAPIService).Get(ctx, fmt.Sprintf("cloud-accounts/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &provider.ErrAPIService{StatusCode: resp.StatusCode, Message: "unable to fetch the cloud account"}
+	}
+
+	var account struct {
+		Data storedAccount `json:"data"`
+	}
+
+	if err = utils.GetResponse(resp, &account); err != nil {
+		return nil, err
+	}
+
+	if string(account.Data.Credentials) == "null" {
+		account.Data.Credentials = nil
+	}
+
+	return &account.Data, nil
+}
+
+// updateAccountCredentials pushes the new key of the cloud account to zop api and confirms zop api returns it.
+// The role profile zop api holds for the account is sent along, so that the update keeps it.
+func updateAccountCredentials(ctx *gofr.Context, acc *list.CloudAccountResponse, creds *serviceAccountCreds,
+	profile *roleProfile) error {
+	body, err := json.Marshal(&request{
+		Name:        acc.Name,
+		Provider:    providerName,
+		Credentials: creds,
+		RoleProfile: profile,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := ctx.GetHTTPService(provider.ZopAPIService).PutWithHeaders(ctx, fmt.Sprintf("cloud-accounts/%d", acc.ID), nil, body,
+		map[string]string{
+			"Content-Type": "application/json",
+		})
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return &provider.ErrAPIService{StatusCode: resp.StatusCode, Message: "unable to update the cloud account credentials"}
+	}
+
+	stored, _, err := getAccountCredentials(ctx, acc.ID)
+	if err != nil {
+		return err
+	}
+
+	if stored.PrivateKeyID != creds.PrivateKeyID {
+		return ErrKeyNotAccepted
+	}
+
+	return nil
+}
+
+// iamKeys manages the keys of gcp service accounts with the IAM API.
+type iamKeys struct{}
+
+func (iamKeys) CreateKey(ctx context.Context, serviceAccount *iam.ServiceAccount) (*iam.ServiceAccountKey, error) {
+	return createServiceAccountKey(ctx, serviceAccount)
+}
+
+func (iamKeys) GetKeyCreationTime(ctx context.Context, name string) (time.Time, error) {
+	return getKeyCreationTime(ctx, name)
+}
+
+func (iamKeys) DeleteKey(ctx context.Context, name string) error {
+	return deleteServiceAccountKey(ctx, name)
+}
+
+// getKeyCreationTime returns the time the service account key was created.
+func getKeyCreationTime(ctx context.Context, name string) (time.Time, error) {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to create IAM client")
+	}
+
+	key, err := iamService.Projects.ServiceAccounts.Keys.Get(name).Do()
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to get service account key")
+	}
+
+	created, err := time.Parse(time.RFC3339, key.ValidAfterTime)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid key creation time")
+	}
+
+	return created, nil
+}
+
+func deleteServiceAccountKey(ctx context.Context, name string) error {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create IAM client")
+	}
+
+	if _, err = iamService.Projects.ServiceAccounts.Keys.Delete(name).Do(); err != nil {
+		return errors.Wrap(err, "failed to delete service account key")
+	}
+
+	return nil
+}
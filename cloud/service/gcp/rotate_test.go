@@ -0,0 +1,260 @@
+package gcp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd/terminal"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/service"
+	"google.golang.org/api/iam/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/service/list"
+)
+
+const (
+	oldKeyName = "projects/payments/serviceAccounts/zop-dev-1@payments.iam.gserviceaccount.com/keys/old"
+	newKeyName = "projects/payments/serviceAccounts/zop-dev-1@payments.iam.gserviceaccount.com/keys/new"
+
+	roleProfileJSON = `"roleProfile": {"name": "minimal-gke", "roles": ["roles/container.developer"]}`
+)
+
+var errKeyDelete = errors.New("permission denied")
+
+func storedAccountResponse(keyID string) *http.Response {
+	body := `{"data": {"credentials": {"type": "service_account", "project_id": "payments", "private_key_id": "` + keyID +
+		`", "client_email": "zop-dev-1@payments.iam.gserviceaccount.com"}, ` + roleProfileJSON + `}}`
+
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}
+}
+
+func statusResponse(status int) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(&bytes.Buffer{})}
+}
+
+func newKey() *iam.ServiceAccountKey {
+	data := `{"type": "service_account", "project_id": "payments", "private_key_id": "new",` +
+		`"client_email": "zop-dev-1@payments.iam.gserviceaccount.com"}`
+
+	return &iam.ServiceAccountKey{Name: newKeyName, PrivateKeyData: base64.StdEncoding.EncodeToString([]byte(data))}
+}
+
+// putBody matches the body of the credential update, which must hold the new key and keep the role profile.
+func putBody() gomock.Matcher {
+	return gomock.Cond(func(body any) bool {
+		b, ok := body.([]byte)
+
+		return ok && bytes.Contains(b, []byte(`"private_key_id":"new"`)) &&
+			bytes.Contains(b, []byte(`"roleProfile":{"name":"minimal-gke","roles":["roles/container.developer"]}`))
+	})
+}
+
+func Test_getAccountCredentials(t *testing.T) {
+	mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+		return service.NewMockHTTP(ctrl)
+	})
+	mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
+	ctx := &gofr.Context{Container: mockCont}
+
+	mocks.HTTPService.EXPECT().Get(ctx, "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil)
+
+	creds, profile, err := getAccountCredentials(ctx, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, "old", creds.PrivateKeyID)
+	assert.Equal(t, &roleProfile{Name: "minimal-gke", Roles: []string{"roles/container.developer"}}, profile)
+
+	mocks.HTTPService.EXPECT().Get(ctx, "cloud-accounts/8", nil).Return(&http.Response{StatusCode: http.StatusOK,
+		Body: io.NopCloser(bytes.NewBufferString(`{"data": {"credentials": {"type": "authorized_user"}}}`))}, nil)
+
+	_, _, err = getAccountCredentials(ctx, 8)
+
+	require.ErrorIs(t, err, ErrNoServiceAccountKey)
+}
+
+func Test_updateAccountCredentials(t *testing.T) {
+	mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+		return service.NewMockHTTP(ctrl)
+	})
+	mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
+	ctx := &gofr.Context{Container: mockCont}
+
+	acc := &list.CloudAccountResponse{ID: 7, Name: "payments", Provider: providerName}
+	creds := &serviceAccountCreds{Type: credTypeServiceAccount, PrivateKeyID: "new"}
+	profile := &roleProfile{Name: "minimal-gke", Roles: []string{"roles/container.developer"}}
+
+	testCases := []struct {
+		name   string
+		status int
+		stored string
+		err    error
+	}{
+		{name: "accepted", status: http.StatusOK, stored: "new"},
+		{name: "not accepted", status: http.StatusNoContent, stored: "old", err: ErrKeyNotAccepted},
+		{name: "rejected", status: http.StatusBadRequest, err: &provider.ErrAPIService{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mocks.HTTPService.EXPECT().PutWithHeaders(ctx, "cloud-accounts/7", nil, putBody(), gomock.Any()).
+				Return(statusResponse(tc.status), nil)
+
+			if tc.stored != "" {
+				mocks.HTTPService.EXPECT().Get(ctx, "cloud-accounts/7", nil).Return(storedAccountResponse(tc.stored), nil)
+			}
+
+			err := updateAccountCredentials(ctx, acc, creds, profile)
+
+			if apiErr := new(provider.ErrAPIService); errors.As(tc.err, &apiErr) {
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tc.status, apiErr.StatusCode)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestService_RotateKeys(t *testing.T) {
+	accounts := []*list.CloudAccountResponse{
+		{ID: 7, Name: "payments", Provider: providerName},
+		{ID: 9, Name: "prod", Provider: "aws"},
+	}
+
+	testCases := []struct {
+		name    string
+		id      int64
+		minAge  time.Duration
+		mocks   func(mockHTTP *service.MockHTTP, keys *MockKeyManager)
+		rotated int
+		err     error
+	}{
+		{
+			name: "rotated",
+			mocks: func(mockHTTP *service.MockHTTP, keys *MockKeyManager) {
+				gomock.InOrder(
+					mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil),
+					keys.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(newKey(), nil),
+					mockHTTP.EXPECT().PutWithHeaders(gomock.Any(), "cloud-accounts/7", nil, putBody(), gomock.Any()).
+						Return(statusResponse(http.StatusOK), nil),
+					mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("new"), nil),
+					keys.EXPECT().DeleteKey(gomock.Any(), oldKeyName).Return(nil),
+				)
+			},
+			rotated: 1,
+		},
+		{
+			name: "no service account key",
+			mocks: func(mockHTTP *service.MockHTTP, _ *MockKeyManager) {
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(&http.Response{StatusCode: http.StatusOK,
+					Body: io.NopCloser(bytes.NewBufferString(`{"data": {"credentials": null}}`))}, nil)
+			},
+		},
+		{
+			name:   "key younger than -older-than",
+			minAge: 30 * hoursPerDay * time.Hour,
+			mocks: func(mockHTTP *service.MockHTTP, keys *MockKeyManager) {
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil)
+				keys.EXPECT().GetKeyCreationTime(gomock.Any(), oldKeyName).Return(time.Now().Add(-24*time.Hour), nil)
+			},
+		},
+		{
+			name:   "key older than -older-than",
+			minAge: 30 * hoursPerDay * time.Hour,
+			mocks: func(mockHTTP *service.MockHTTP, keys *MockKeyManager) {
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil)
+				keys.EXPECT().GetKeyCreationTime(gomock.Any(), oldKeyName).
+					Return(time.Now().Add(-90*hoursPerDay*time.Hour), nil)
+				keys.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(newKey(), nil)
+				mockHTTP.EXPECT().PutWithHeaders(gomock.Any(), "cloud-accounts/7", nil, putBody(), gomock.Any()).
+					Return(statusResponse(http.StatusOK), nil)
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("new"), nil)
+				keys.EXPECT().DeleteKey(gomock.Any(), oldKeyName).Return(nil)
+			},
+			rotated: 1,
+		},
+		{
+			name: "new key rejected by zop api",
+			mocks: func(mockHTTP *service.MockHTTP, keys *MockKeyManager) {
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil)
+				keys.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(newKey(), nil)
+				mockHTTP.EXPECT().PutWithHeaders(gomock.Any(), "cloud-accounts/7", nil, putBody(), gomock.Any()).
+					Return(statusResponse(http.StatusInternalServerError), nil)
+				keys.EXPECT().DeleteKey(gomock.Any(), newKeyName).Return(nil)
+			},
+			err: &provider.ErrAPIService{},
+		},
+		{
+			name: "new key not accepted by zop api",
+			mocks: func(mockHTTP *service.MockHTTP, keys *MockKeyManager) {
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil)
+				keys.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(newKey(), nil)
+				mockHTTP.EXPECT().PutWithHeaders(gomock.Any(), "cloud-accounts/7", nil, putBody(), gomock.Any()).
+					Return(statusResponse(http.StatusOK), nil)
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil)
+				keys.EXPECT().DeleteKey(gomock.Any(), newKeyName).Return(nil)
+			},
+			err: ErrKeyNotAccepted,
+		},
+		{
+			name: "old key not deleted",
+			mocks: func(mockHTTP *service.MockHTTP, keys *MockKeyManager) {
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("old"), nil)
+				keys.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(newKey(), nil)
+				mockHTTP.EXPECT().PutWithHeaders(gomock.Any(), "cloud-accounts/7", nil, putBody(), gomock.Any()).
+					Return(statusResponse(http.StatusOK), nil)
+				mockHTTP.EXPECT().Get(gomock.Any(), "cloud-accounts/7", nil).Return(storedAccountResponse("new"), nil)
+				keys.EXPECT().DeleteKey(gomock.Any(), oldKeyName).Return(errKeyDelete)
+			},
+			err: errKeyDelete,
+		},
+		{
+			name:  "other account selected",
+			id:    8,
+			mocks: func(*service.MockHTTP, *MockKeyManager) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+				return service.NewMockHTTP(ctrl)
+			})
+			mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
+			ctx := &gofr.Context{Container: mockCont, Out: terminal.New()}
+
+			ctrl := gomock.NewController(t)
+			mockGetter := NewMockAccountGetter(ctrl)
+			mockKeys := NewMockKeyManager(ctrl)
+
+			mockGetter.EXPECT().GetAccounts(ctx).Return(accounts, nil)
+			tc.mocks(mocks.HTTPService, mockKeys)
+
+			s := &Service{accountGetter: mockGetter, keys: mockKeys}
+
+			rotated, err := s.RotateKeys(ctx, tc.id, tc.minAge)
+
+			assert.Equal(t, tc.rotated, rotated)
+
+			if apiErr := new(provider.ErrAPIService); errors.As(tc.err, &apiErr) {
+				require.ErrorAs(t, err, &apiErr)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
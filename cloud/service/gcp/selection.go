@@ -0,0 +1,262 @@
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/store/gcp"
+	"zop.dev/cli/zop/utils"
+)
+
+const (
+	accountListTitle = "Select the gcloud accounts to import!"
+	projectListTitle = "Select the projects to create a zop service account in for %s!"
+)
+
+var (
+	// ErrAccountNotFound is returned when the account given with -account is not present in the gcloud credentials.
+	ErrAccountNotFound = errors.New("gcloud account not found")
+
+	// ErrUnableToRenderList is returned when the list of accounts or projects cannot be rendered.
+	ErrUnableToRenderList = errors.New("unable to render the list")
+
+	// ErrImportCancelled is returned when the user does not confirm the selected accounts and projects.
+	ErrImportCancelled = errors.New("import cancelled, nothing was created")
+)
+
+// getImportPlans returns what is imported for the selected gcloud accounts. The accounts and projects are
+// taken from -account and -projects, the projects of user accounts are narrowed down with -project-filter,
+// anything not given with the flags is selected by the user in a list.
+// When the user selected anything or service accounts are going to be created, nothing is created until the
+// user confirms the final selection. A dry run does not ask for confirmation as it creates nothing, neither is
+// it asked when stdin is not a terminal. The accounts whose projects could not be read are returned as failed
+// results.
+func getImportPlans(ctx *gofr.Context, accounts []gcp.AccountStore, profile *roleProfile) ([]*accountPlan,
+	[]provider.Result, error) {
+	accountID := ctx.Param("account")
+	projectIDs := splitParam(ctx.Param("projects"))
+
+	filter, err := parseProjectFilter(ctx.Param("project-filter"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Scripted use only gives -projects, in which case every account is considered.
+	interactive := accountID == "" && len(projectIDs) == 0
+
+	// picked reports whether the user selected accounts or projects from a list.
+	picked := interactive && len(accounts) > 1
+
+	selected, err := selectAccounts(ctx, accounts, accountID, interactive)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plans := make([]*accountPlan, 0, len(selected))
+
+	var failed []provider.Result
+
+	for i := range selected {
+		plan, er := newAccountPlan(ctx, &selected[i], profile, filter)
+		if errors.Is(er, ErrLocalCredentialSource) {
+			failed = append(failed, provider.Skipped(selected[i].AccountID, "", er))
+
+			continue
+		}
+
+		if er != nil {
+			ctx.Logger.Errorf("error getting account %s: %v", selected[i].AccountID, er)
+
+			failed = append(failed, provider.Failed(selected[i].AccountID, "", er))
+
+			continue
+		}
+
+		if plan.credential == nil {
+			if len(projectIDs) != 0 {
+				plan.projects = filterProjects(plan.projects, projectIDs)
+			} else {
+				picked = picked || len(plan.projects) != 0
+
+				if plan.projects, er = selectProjects(ctx, plan); er != nil {
+					return nil, nil, er
+				}
+			}
+
+			if len(plan.projects) == 0 {
+				continue
+			}
+		}
+
+		plans = append(plans, plan)
+	}
+
+	if needsConfirmation(picked, plans) && !provider.DryRun(ctx) && utils.IsTerminal(os.Stdin) &&
+		!confirmPlans(ctx, plans) {
+		return nil, nil, ErrImportCancelled
+	}
+
+	return plans, failed, nil
+}
+
+// selectAccounts returns the account given with -account, or the accounts selected by the user.
+func selectAccounts(ctx *gofr.Context, accounts []gcp.AccountStore, accountID string, interactive bool) ([]gcp.AccountStore, error) {
+	if accountID != "" {
+		for i := range accounts {
+			if accounts[i].AccountID == accountID {
+				return accounts[i : i+1], nil
+			}
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, accountID)
+	}
+
+	if !interactive || len(accounts) <= 1 {
+		return accounts, nil
+	}
+
+	items := make([]*utils.Item, 0, len(accounts))
+
+	for i := range accounts {
+		items = append(items, &utils.Item{Name: accounts[i].AccountID, Data: &accounts[i]})
+	}
+
+	choices, err := utils.RenderMultiList(accountListTitle, items)
+	if err != nil {
+		ctx.Logger.Errorf("unable to render the list of gcloud accounts! %v", err)
+
+		return nil, ErrUnableToRenderList
+	}
+
+	if choices == nil {
+		return nil, ErrImportCancelled
+	}
+
+	selected := make([]gcp.AccountStore, 0, len(choices))
+
+	for _, choice := range choices {
+		selected = append(selected, *choice.Data.(*gcp.AccountStore))
+	}
+
+	return selected, nil
+}
+
+// selectProjects returns the projects of a user account selected by the user.
+func selectProjects(ctx *gofr.Context, plan *accountPlan) ([]*cloudresourcemanager.Project, error) {
+	if len(plan.projects) == 0 {
+		return nil, nil
+	}
+
+	items := make([]*utils.Item, 0, len(plan.projects))
+
+	for _, p := range plan.projects {
+		items = append(items, &utils.Item{Name: p.ProjectId, Data: p})
+	}
+
+	choices, err := utils.RenderMultiList(fmt.Sprintf(projectListTitle, plan.accountID), items)
+	if err != nil {
+		ctx.Logger.Errorf("unable to render the list of projects! %v", err)
+
+		return nil, ErrUnableToRenderList
+	}
+
+	if choices == nil {
+		return nil, ErrImportCancelled
+	}
+
+	projects := make([]*cloudresourcemanager.Project, 0, len(choices))
+
+	for _, choice := range choices {
+		projects = append(projects, choice.Data.(*cloudresourcemanager.Project))
+	}
+
+	return projects, nil
+}
+
+// filterProjects returns the projects whose id is one of projectIDs.
+func filterProjects(projects []*cloudresourcemanager.Project, projectIDs []string) []*cloudresourcemanager.Project {
+	filtered := make([]*cloudresourcemanager.Project, 0, len(projectIDs))
+
+	for _, p := range projects {
+		for _, id := range projectIDs {
+			if p.ProjectId == id {
+				filtered = append(filtered, p)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// needsConfirmation reports whether the user has to confirm the plans: when the user picked them from a list,
+// or when service accounts are going to be created. Existing keys and configs given on the command line or
+// found in the environment, like on CI runners, are imported without asking.
+func needsConfirmation(picked bool, plans []*accountPlan) bool {
+	if picked {
+		return true
+	}
+
+	for _, plan := range plans {
+		if plan.credential == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// confirmPlans prints what is going to be imported and asks the user to confirm it, on stderr unless a table
+// is rendered to a terminal.
+func confirmPlans(ctx *gofr.Context, plans []*accountPlan) bool {
+	if len(plans) == 0 {
+		return true
+	}
+
+	w := utils.Messages(ctx)
+
+	fmt.Fprintln(w, "The following accounts will be imported:")
+
+	for _, plan := range plans {
+		if plan.credential != nil {
+			fmt.Fprintf(w, "  %s: existing %s\n", plan.accountID, plan.credential.kind)
+
+			continue
+		}
+
+		ids := make([]string, 0, len(plan.projects))
+		for _, p := range plan.projects {
+			ids = append(ids, p.ProjectId)
+		}
+
+		fmt.Fprintf(w, "  %s: zop service account with role profile %s in project(s) %s\n",
+			plan.accountID, plan.roleProfile.Name, strings.Join(ids, ", "))
+	}
+
+	var input string
+
+	fmt.Fprint(w, "Do you wish to continue? (y/n) ")
+
+	_, _ = fmt.Scanf("%s", &input)
+
+	return strings.EqualFold(input, "y")
+}
+
+// splitParam splits a comma separated flag value, ignoring empty values.
+func splitParam(param string) []string {
+	values := make([]string, 0)
+
+	for _, v := range strings.Split(param, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,98 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"zop.dev/cli/zop/cloud/store/gcp"
+)
+
+func Test_getImportPlans_Flags(t *testing.T) {
+	accounts := []gcp.AccountStore{
+		{AccountID: "ci@proj.iam.gserviceaccount.com", Value: []byte(`{"type":"service_account","private_key":"key"}`)},
+		{AccountID: "deploy@proj.iam.gserviceaccount.com", Value: []byte(`{"type":"service_account","private_key":"key"}`)},
+	}
+
+	testCases := []struct {
+		name     string
+		args     []string
+		accounts []gcp.AccountStore
+		expected []string
+		expErr   error
+	}{
+		{
+			name:     "account flag",
+			args:     []string{"", "-account=deploy@proj.iam.gserviceaccount.com"},
+			expected: []string{"deploy@proj.iam.gserviceaccount.com"},
+		},
+		{
+			name:     "projects flag keeps every service account key",
+			args:     []string{"", "-projects=proj"},
+			expected: []string{"ci@proj.iam.gserviceaccount.com", "deploy@proj.iam.gserviceaccount.com"},
+		},
+		{
+			name:     "single key without flags is not confirmed",
+			args:     []string{""},
+			accounts: accounts[:1],
+			expected: []string{"ci@proj.iam.gserviceaccount.com"},
+		},
+		{
+			name:   "unknown account",
+			args:   []string{"", "-account=missing@example.com"},
+			expErr: ErrAccountNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := &gofr.Context{
+				Container: &container.Container{Logger: logging.NewMockLogger(logging.INFO)},
+				Request:   cmd.NewRequest(tc.args),
+			}
+
+			available := accounts
+			if tc.accounts != nil {
+				available = tc.accounts
+			}
+
+			plans, _, err := getImportPlans(ctx, available, nil)
+
+			require.ErrorIs(t, err, tc.expErr)
+
+			ids := make([]string, 0, len(plans))
+			for _, p := range plans {
+				require.NotNil(t, p.credential)
+
+				ids = append(ids, p.accountID)
+			}
+
+			if tc.expErr == nil {
+				require.Equal(t, tc.expected, ids)
+			}
+		})
+	}
+}
+
+func Test_filterProjects(t *testing.T) {
+	projects := []*cloudresourcemanager.Project{{ProjectId: "payments"}, {ProjectId: "search"}, {ProjectId: "infra"}}
+
+	filtered := filterProjects(projects, splitParam(" infra, ,payments,unknown"))
+
+	require.Equal(t, []*cloudresourcemanager.Project{{ProjectId: "payments"}, {ProjectId: "infra"}}, filtered)
+}
+
+func Test_needsConfirmation(t *testing.T) {
+	key := &accountPlan{accountID: "ci@proj.iam.gserviceaccount.com", credential: &credential{}}
+	user := &accountPlan{accountID: "dev@example.com", projects: []*cloudresourcemanager.Project{{ProjectId: "proj"}}}
+
+	require.False(t, needsConfirmation(false, []*accountPlan{key}))
+	require.True(t, needsConfirmation(true, []*accountPlan{key}))
+	require.True(t, needsConfirmation(false, []*accountPlan{key, user}))
+	require.False(t, needsConfirmation(false, nil))
+}
@@ -2,17 +2,18 @@
 package gcp
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 
 	"gofr.dev/pkg/gofr"
-	"gofr.dev/pkg/gofr/cmd/terminal"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/config"
 )
 
 const (
-	ZopAPIService = "api-service"
 	GcloudService = "gcloud-service"
+
+	providerName = "gcp"
 )
 
 var (
@@ -23,73 +24,112 @@ var (
 
 // Service is a service for importing GCP service accounts into zop api service.
 type Service struct {
-	store AccountStore
+	store              AccountStore
+	accountGetter      AccountGetter
+	keys               KeyManager
+	roleProfiles       map[string][]string
+	defaultRoleProfile string
+	apiServiceAccount  string
 }
 
-func New(store AccountStore) *Service {
+// New creates a new Service importing the accounts read by the store, accountGetter is used
+// to look up the accounts already present in zop api. The role profiles of cfg are added to the built-in ones.
+func New(store AccountStore, accountGetter AccountGetter, cfg *config.Config) *Service {
+	profiles := builtinRoleProfiles()
+
+	for name, roles := range cfg.RoleProfiles {
+		profiles[name] = roles
+	}
+
+	defaultProfile := cfg.DefaultRoleProfile
+	if defaultProfile == "" {
+		defaultProfile = roleProfileFull
+	}
+
 	return &Service{
-		store: store,
+		store:              store,
+		accountGetter:      accountGetter,
+		keys:               iamKeys{},
+		roleProfiles:       profiles,
+		defaultRoleProfile: defaultProfile,
+		apiServiceAccount:  cfg.APIServiceAccount,
 	}
 }
 
-type ErrAPIService struct {
-	StatusCode int
-	Message    string
+// NewProvider returns the gcp provider for the registry, importing the gcloud accounts read by the store
+// from the credential sources reported by discover.
+func NewProvider(store AccountStore, accountGetter AccountGetter, cfg *config.Config,
+	discover provider.DiscoverFunc) *provider.Provider {
+	return &provider.Provider{
+		Name:     providerName,
+		Discover: discover,
+		Importer: New(store, accountGetter, cfg),
+	}
 }
 
-func (e *ErrAPIService) Error() string {
-	return fmt.Sprintf("error from api service: %s, status code: %d", e.Message, e.StatusCode)
+// getImportedProjects returns the ids of the projects zop api already has a gcp cloud account for.
+func (s *Service) getImportedProjects(ctx *gofr.Context) (map[string]bool, error) {
+	accounts, err := s.accountGetter.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imported := make(map[string]bool)
+
+	for _, acc := range accounts {
+		if acc.Provider == providerName {
+			imported[acc.ProviderID] = true
+		}
+	}
+
+	return imported, nil
 }
 
 // PostAccounts posts the GCP service accounts to the api service.
 // It fetches the accounts from the store layer and posts them to the api service.
-// If an account is of type user account, it generates a token and then creates a service account.
-func (s *Service) PostAccounts(ctx *gofr.Context) error {
+// If an account is of type user account, it generates a token and then creates a service account key
+// in each project selected by the user, after the user confirms the selection. Existing zop service accounts
+// are reused and projects zop api already has credentials for are skipped. The service accounts
+// are granted the roles of the profile selected with -role-profile.
+// With -dry-run it only prints what would be imported.
+func (s *Service) PostAccounts(ctx *gofr.Context) ([]provider.Result, error) {
+	profile, err := s.getRoleProfile(ctx.Param("role-profile"))
+	if err != nil {
+		return nil, err
+	}
+
 	accounts, err := s.store.GetAccounts(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	api := ctx.GetHTTPService(ZopAPIService)
+	plans, results, err := getImportPlans(ctx, accounts, profile)
+	if err != nil {
+		return nil, err
+	}
 
-	defer terminal.NewDotSpinner(ctx.Out).Spin(ctx).Stop()
+	imported, err := s.getImportedProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, acc := range accounts {
-		svAccs, er := getServiceAccounts(ctx, acc.Value)
+	if provider.DryRun(ctx) {
+		planned, er := printPlan(ctx, plans, imported)
 		if er != nil {
-			ctx.Logger.Errorf("error getting service accounts: %v", er)
-
-			continue
+			return nil, er
 		}
 
-		for _, svcAcc := range svAccs {
-			body, er := json.Marshal(&request{
-				Name:        acc.AccountID,
-				Provider:    "gcp",
-				Credentials: svcAcc,
-			})
-			if er != nil {
-				ctx.Logger.Errorf("error marshaling account creds: %v", er)
-				continue
-			}
-
-			resp, er := api.PostWithHeaders(ctx, "cloud-accounts", nil, body, map[string]string{
-				"Content-Type": "application/json",
-			})
-			if er != nil {
-				ctx.Logger.Errorf("error posting account: %v", er)
-				continue
-			}
-
-			if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-				ctx.Logger.Errorf("error posting account: %v", resp.Body)
-
-				return &ErrAPIService{StatusCode: resp.StatusCode, Message: "could not connect to the zop-api service"}
-			}
-
-			resp.Body.Close()
-		}
+		return append(results, planned...), nil
 	}
 
-	return nil
+	parallel, err := getParallelism(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, skipped := newImportTasks(ctx, plans, imported)
+
+	results = append(results, skipped...)
+
+	return append(results, runImportTasks(ctx, tasks, parallel, s.apiServiceAccount)...), nil
 }
@@ -0,0 +1,255 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gofr.dev/pkg/gofr"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iam/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/cloud/service/list"
+)
+
+const (
+	checkCredentials    = "credentials"
+	checkToken          = "token"
+	checkServiceAccount = "service account"
+	checkKey            = "key"
+	checkRoles          = "roles"
+	checkLogin          = "gcloud login"
+)
+
+// VerifyAccounts checks the health of the cloud accounts in zop api, or of the account with the given id
+// if it is not zero. For gcp accounts it checks that the stored credentials still mint a token, that the
+// service account and its key are neither disabled nor deleted and that the roles of the role profile are
+// still bound. Without an id the gcloud user accounts on this machine are checked for expired or revoked
+// tokens as well, they would fail the next import.
+func (s *Service) VerifyAccounts(ctx *gofr.Context, id int64) ([]provider.Check, error) {
+	accounts, err := s.accountGetter.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []provider.Check
+
+	for _, acc := range accounts {
+		if id != 0 && acc.ID != id {
+			continue
+		}
+
+		if acc.Provider != providerName {
+			checks = append(checks, provider.Check{Provider: acc.Provider, Account: acc.Name, Check: checkCredentials,
+				Status: provider.CheckWarn, Detail: "not verified, only gcp accounts can be verified"})
+
+			continue
+		}
+
+		for _, c := range s.verifyAccount(ctx, acc) {
+			c.Provider, c.Account = providerName, acc.Name
+			checks = append(checks, c)
+		}
+	}
+
+	if id == 0 {
+		checks = append(checks, s.verifyLogins(ctx)...)
+	}
+
+	return checks, nil
+}
+
+// verifyAccount runs the checks of a single gcp cloud account.
+func (s *Service) verifyAccount(ctx *gofr.Context, acc *list.CloudAccountResponse) []provider.Check {
+	stored, err := fetchAccount(ctx, acc.ID)
+	if err != nil {
+		return []provider.Check{failed(checkCredentials, err)}
+	}
+
+	info, err := parseCredentialInfo(stored.Credentials)
+	if err != nil || len(stored.Credentials) == 0 {
+		return []provider.Check{{Check: checkCredentials, Status: provider.CheckFail, Detail: "no readable credentials"}}
+	}
+
+	checks := []provider.Check{verifyToken(ctx, stored.Credentials)}
+
+	if info.ServiceAccount == "" {
+		return checks
+	}
+
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return append(checks, warned(checkServiceAccount, errors.Wrap(err, "failed to create IAM client")))
+	}
+
+	serviceAccount, err := iamService.Projects.ServiceAccounts.Get("projects/-/serviceAccounts/" + info.ServiceAccount).Do()
+
+	checks = append(checks, serviceAccountCheck(serviceAccount, err))
+	if err != nil {
+		return checks
+	}
+
+	if info.KeyID != "" {
+		key, er := iamService.Projects.ServiceAccounts.Keys.Get(serviceAccount.Name + "/keys/" + info.KeyID).Do()
+
+		checks = append(checks, keyCheck(key, er, time.Now()))
+	}
+
+	return append(checks, s.verifyRoles(ctx, info.ServiceAccount, stored.RoleProfile))
+}
+
+// verifyToken checks that service account keys still mint an access token. Other credentials are used by
+// zop api with its own identity, they cannot be checked from here.
+func verifyToken(ctx context.Context, raw json.RawMessage) provider.Check {
+	kind, err := credentialType(raw)
+	if err != nil {
+		return failed(checkToken, err)
+	}
+
+	if kind != credTypeServiceAccount {
+		return provider.Check{Check: checkToken, Status: provider.CheckWarn,
+			Detail: fmt.Sprintf("not checked, %s tokens are minted by zop-api", kind)}
+	}
+
+	creds, err := google.CredentialsFromJSON(ctx, raw, iam.CloudPlatformScope)
+	if err != nil {
+		return failed(checkToken, err)
+	}
+
+	if _, err = creds.TokenSource.Token(); err != nil {
+		return failed(checkToken, errors.Wrap(err, "unable to mint a token"))
+	}
+
+	return provider.Check{Check: checkToken, Status: provider.CheckPass}
+}
+
+// verifyRoles checks that the zop service account is still bound to the roles of its role profile in the
+// IAM policy of its project. The default role profile is expected when zop api has none for the account.
+func (s *Service) verifyRoles(ctx context.Context, email string, profile *roleProfile) provider.Check {
+	if !strings.HasPrefix(email, serviceAccountPrefix) {
+		return provider.Check{Check: checkRoles, Status: provider.CheckWarn,
+			Detail: "not checked, the service account was not created by zop"}
+	}
+
+	if profile == nil || len(profile.Roles) == 0 {
+		var err error
+
+		if profile, err = s.getRoleProfile(""); err != nil {
+			return warned(checkRoles, err)
+		}
+	}
+
+	crmService, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		return warned(checkRoles, errors.Wrap(err, "failed to create Cloud Resource Manager client"))
+	}
+
+	policy, err := crmService.Projects.GetIamPolicy(serviceAccountProject(email),
+		&cloudresourcemanager.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		return warned(checkRoles, errors.Wrap(err, "failed to get IAM policy"))
+	}
+
+	return rolesCheck(policy, "serviceAccount:"+email, profile)
+}
+
+// verifyLogins checks that the gcloud user accounts on this machine can still refresh their tokens.
+func (s *Service) verifyLogins(ctx *gofr.Context) []provider.Check {
+	accounts, err := s.store.GetAccounts(ctx)
+	if err != nil {
+		return []provider.Check{{Provider: providerName, Check: checkLogin, Status: provider.CheckWarn,
+			Detail: fmt.Sprintf("unable to read the local gcloud accounts: %v", err)}}
+	}
+
+	var checks []provider.Check
+
+	for _, acc := range accounts {
+		if kind, er := credentialType(acc.Value); er != nil || kind != credTypeAuthorizedUser {
+			continue
+		}
+
+		check := provider.Check{Provider: providerName, Account: acc.AccountID, Check: checkLogin, Status: provider.CheckPass}
+
+		var creds userAccountCreds
+
+		if er := json.Unmarshal(acc.Value, &creds); er != nil {
+			check.Status, check.Detail = provider.CheckFail, er.Error()
+		} else if _, er = refreshAccessToken(ctx, creds.ClientID, creds.ClientSecret, creds.RefreshToken); er != nil {
+			check.Status, check.Detail = provider.CheckFail, "token expired or revoked, run gcloud auth login"
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// serviceAccountCheck turns the lookup of a service account into a check.
+func serviceAccountCheck(serviceAccount *iam.ServiceAccount, err error) provider.Check {
+	switch {
+	case isNotFound(err):
+		return provider.Check{Check: checkServiceAccount, Status: provider.CheckFail, Detail: "service account deleted"}
+	case err != nil:
+		return warned(checkServiceAccount, err)
+	case serviceAccount.Disabled:
+		return provider.Check{Check: checkServiceAccount, Status: provider.CheckFail, Detail: "service account disabled"}
+	default:
+		return provider.Check{Check: checkServiceAccount, Status: provider.CheckPass, Detail: serviceAccount.Email}
+	}
+}
+
+// keyCheck turns the lookup of a service account key into a check.
+func keyCheck(key *iam.ServiceAccountKey, err error, now time.Time) provider.Check {
+	switch {
+	case isNotFound(err):
+		return provider.Check{Check: checkKey, Status: provider.CheckFail, Detail: "key deleted"}
+	case err != nil:
+		return warned(checkKey, err)
+	case key.Disabled:
+		return provider.Check{Check: checkKey, Status: provider.CheckFail, Detail: "key disabled"}
+	}
+
+	if validBefore, er := time.Parse(time.RFC3339, key.ValidBeforeTime); er == nil && validBefore.Before(now) {
+		return provider.Check{Check: checkKey, Status: provider.CheckFail, Detail: "key expired"}
+	}
+
+	if validAfter, er := time.Parse(time.RFC3339, key.ValidAfterTime); er == nil {
+		return provider.Check{Check: checkKey, Status: provider.CheckPass,
+			Detail: fmt.Sprintf("%d days old", int(now.Sub(validAfter).Hours()/hoursPerDay))}
+	}
+
+	return provider.Check{Check: checkKey, Status: provider.CheckPass}
+}
+
+// rolesCheck checks that the member is bound to every role of the profile in the policy.
+func rolesCheck(policy *cloudresourcemanager.Policy, member string, profile *roleProfile) provider.Check {
+	if missing := missingRoles(policy, member, profile.Roles); len(missing) > 0 {
+		return provider.Check{Check: checkRoles, Status: provider.CheckFail,
+			Detail: fmt.Sprintf("missing %s of role profile %s", strings.Join(missing, ", "), profile.Name)}
+	}
+
+	return provider.Check{Check: checkRoles, Status: provider.CheckPass,
+		Detail: fmt.Sprintf("role profile %s bound", profile.Name)}
+}
+
+// isNotFound reports whether the Google API call failed because the resource does not exist.
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+func failed(name string, err error) provider.Check {
+	return provider.Check{Check: name, Status: provider.CheckFail, Detail: err.Error()}
+}
+
+func warned(name string, err error) provider.Check {
+	return provider.Check{Check: name, Status: provider.CheckWarn, Detail: "not checked: " + err.Error()}
+}
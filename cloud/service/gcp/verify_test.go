@@ -0,0 +1,86 @@
+package gcp
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iam/v1"
+
+	"zop.dev/cli/zop/cloud/provider"
+)
+
+var errPermissionDenied = errors.New("permission denied")
+
+func Test_keyCheck(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		key      *iam.ServiceAccountKey
+		err      error
+		expected provider.Check
+	}{
+		{
+			name:     "valid key",
+			key:      &iam.ServiceAccountKey{ValidAfterTime: "2024-05-01T00:00:00Z", ValidBeforeTime: "9999-12-31T23:59:59Z"},
+			expected: provider.Check{Check: checkKey, Status: provider.CheckPass, Detail: "31 days old"},
+		},
+		{
+			name:     "disabled key",
+			key:      &iam.ServiceAccountKey{Disabled: true},
+			expected: provider.Check{Check: checkKey, Status: provider.CheckFail, Detail: "key disabled"},
+		},
+		{
+			name:     "expired key",
+			key:      &iam.ServiceAccountKey{ValidBeforeTime: "2024-05-01T00:00:00Z"},
+			expected: provider.Check{Check: checkKey, Status: provider.CheckFail, Detail: "key expired"},
+		},
+		{
+			name:     "deleted key",
+			err:      &googleapi.Error{Code: http.StatusNotFound},
+			expected: provider.Check{Check: checkKey, Status: provider.CheckFail, Detail: "key deleted"},
+		},
+		{
+			name:     "lookup error",
+			err:      errPermissionDenied,
+			expected: provider.Check{Check: checkKey, Status: provider.CheckWarn, Detail: "not checked: permission denied"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, keyCheck(tc.key, tc.err, now))
+		})
+	}
+}
+
+func Test_serviceAccountCheck(t *testing.T) {
+	email := "zop-dev-1@proj.iam.gserviceaccount.com"
+
+	assert.Equal(t, provider.CheckPass, serviceAccountCheck(&iam.ServiceAccount{Email: email}, nil).Status)
+	assert.Equal(t, "service account disabled", serviceAccountCheck(&iam.ServiceAccount{Disabled: true}, nil).Detail)
+	assert.Equal(t, "service account deleted",
+		serviceAccountCheck(nil, &googleapi.Error{Code: http.StatusNotFound}).Detail)
+}
+
+func Test_rolesCheck(t *testing.T) {
+	member := "serviceAccount:zop-dev-1@proj.iam.gserviceaccount.com"
+	profile := &roleProfile{Name: "custom", Roles: []string{"roles/viewer", "roles/container.developer"}}
+
+	policy := &cloudresourcemanager.Policy{Bindings: []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{member}},
+	}}
+
+	assert.Equal(t, provider.Check{Check: checkRoles, Status: provider.CheckFail,
+		Detail: "missing roles/container.developer of role profile custom"}, rolesCheck(policy, member, profile))
+
+	addMember(policy, member, profile.Roles)
+
+	assert.Equal(t, provider.Check{Check: checkRoles, Status: provider.CheckPass,
+		Detail: "role profile custom bound"}, rolesCheck(policy, member, profile))
+}
@@ -1,5 +1,11 @@
 package list
 
+import (
+	"time"
+
+	"zop.dev/cli/zop/utils"
+)
+
 type CloudAccountResponse struct {
 	// Name is the name of the cloud account.
 	Name string `json:"name"`
@@ -17,11 +23,35 @@ type CloudAccountResponse struct {
 	ProviderDetails any `json:"providerDetails"`
 
 	// CreatedAt is the timestamp of when the cloud account was created.
-	CreatedAt string `json:"createdAt"`
+	CreatedAt utils.Timestamp `json:"createdAt"`
 
 	// UpdatedAt is the timestamp of the last update to the cloud account.
-	UpdatedAt string `json:"updatedAt"`
+	UpdatedAt utils.Timestamp `json:"updatedAt"`
 
 	// DeletedAt is the timestamp of when the cloud account was deleted, if applicable.
 	DeletedAt string `json:"deletedAt,omitempty"`
 }
+
+// DeploymentSpace is a deployment space attached to a cloud account.
+type DeploymentSpace struct {
+	// Name is the name of the deployment space, like the name of the cluster.
+	Name string `json:"name"`
+
+	// Type is the kind of the deployment space, like gke or eks.
+	Type string `json:"type"`
+
+	// Environment is the name of the environment the deployment space belongs to.
+	Environment string `json:"environment,omitempty"`
+}
+
+// CredentialInfo describes the credentials zop api holds for a cloud account.
+type CredentialInfo struct {
+	// ServiceAccount is the email of the service account the credentials belong to.
+	ServiceAccount string
+
+	// KeyID is the id of the service account key, it is empty for keyless credentials.
+	KeyID string
+
+	// KeyCreatedAt is the time the key was created, it is zero when it is unknown.
+	KeyCreatedAt time.Time
+}
@@ -2,9 +2,13 @@ package list
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
 
 	"gofr.dev/pkg/gofr"
+
+	"zop.dev/cli/zop/cloud/provider"
 )
 
 type Service struct {
@@ -15,7 +19,7 @@ func New() *Service {
 }
 
 func (*Service) GetAccounts(ctx *gofr.Context) ([]*CloudAccountResponse, error) {
-	api := ctx.GetHTTPService("api-service")
+	api := ctx.GetHTTPService(provider.ZopAPIService)
 
 	reps, err := api.Get(ctx, "/cloud-accounts", nil)
 	if err != nil {
@@ -36,3 +40,45 @@ func (*Service) GetAccounts(ctx *gofr.Context) ([]*CloudAccountResponse, error)
 
 	return accounts.Data, nil
 }
+
+// DeleteAccount deletes the cloud account with the given id from zop api.
+func (*Service) DeleteAccount(ctx *gofr.Context, id int64) error {
+	api := ctx.GetHTTPService(provider.ZopAPIService)
+
+	resp, err := api.Delete(ctx, fmt.Sprintf("/cloud-accounts/%d", id), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return &provider.ErrAPIService{StatusCode: resp.StatusCode, Message: "unable to delete the cloud account"}
+	}
+
+	return nil
+}
+
+// GetDeploymentSpaces returns the deployment spaces attached to the cloud account with the given id.
+func (*Service) GetDeploymentSpaces(ctx *gofr.Context, id int64) ([]*DeploymentSpace, error) {
+	api := ctx.GetHTTPService(provider.ZopAPIService)
+
+	resp, err := api.Get(ctx, fmt.Sprintf("/cloud-accounts/%d/deployment-spaces", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &provider.ErrAPIService{StatusCode: resp.StatusCode, Message: "unable to fetch the deployment spaces"}
+	}
+
+	var spaces struct {
+		Data []*DeploymentSpace `json:"data"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&spaces); err != nil {
+		return nil, err
+	}
+
+	return spaces.Data, nil
+}
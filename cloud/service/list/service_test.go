@@ -13,6 +13,9 @@ import (
 	"gofr.dev/pkg/gofr/cmd/terminal"
 	"gofr.dev/pkg/gofr/container"
 	"gofr.dev/pkg/gofr/service"
+
+	"zop.dev/cli/zop/cloud/provider"
+	"zop.dev/cli/zop/utils"
 )
 
 var (
@@ -27,7 +30,7 @@ func Test_Service_GetAccounts(t *testing.T) {
 	mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
 		return service.NewMockHTTP(ctrl)
 	})
-	mockCont.Services["api-service"] = mocks.HTTPService
+	mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
 	ctx := &gofr.Context{Container: mockCont, Out: terminal.New()}
 
 	testCases := []struct {
@@ -46,7 +49,8 @@ func Test_Service_GetAccounts(t *testing.T) {
 					}, nil),
 			},
 			expResult: []*CloudAccountResponse{
-				{Name: "Account1", Provider: "AWS", ProviderID: "12345", UpdatedAt: "2024-01-01", CreatedAt: "2023-01-01"},
+				{Name: "Account1", Provider: "AWS", ProviderID: "12345", UpdatedAt: utils.ParseTimestamp("2024-01-01"),
+					CreatedAt: utils.ParseTimestamp("2023-01-01")},
 			},
 			expError: nil,
 		},
@@ -88,3 +92,105 @@ func Test_Service_GetAccounts(t *testing.T) {
 		})
 	}
 }
+
+func Test_Service_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+		return service.NewMockHTTP(ctrl)
+	})
+	mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
+	ctx := &gofr.Context{Container: mockCont, Out: terminal.New()}
+
+	testCases := []struct {
+		name      string
+		mockCalls []*gomock.Call
+		expError  error
+	}{
+		{
+			name: "successful DELETE call",
+			mockCalls: []*gomock.Call{
+				mocks.HTTPService.EXPECT().Delete(ctx, "/cloud-accounts/7", nil).
+					Return(&http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(&bytes.Buffer{})}, nil),
+			},
+		},
+		{
+			name: "error during DELETE call",
+			mockCalls: []*gomock.Call{
+				mocks.HTTPService.EXPECT().Delete(ctx, "/cloud-accounts/7", nil).
+					Return(nil, errFailFetch),
+			},
+			expError: errFailFetch,
+		},
+		{
+			name: "account not found",
+			mockCalls: []*gomock.Call{
+				mocks.HTTPService.EXPECT().Delete(ctx, "/cloud-accounts/7", nil).
+					Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(&bytes.Buffer{})}, nil),
+			},
+			expError: &provider.ErrAPIService{StatusCode: http.StatusNotFound, Message: "unable to delete the cloud account"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New().DeleteAccount(ctx, 7)
+
+			require.Equal(t, tt.expError, err)
+		})
+	}
+}
+
+func Test_Service_GetDeploymentSpaces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCont, mocks := container.NewMockContainer(t, func(_ *container.Container, ctrl *gomock.Controller) any {
+		return service.NewMockHTTP(ctrl)
+	})
+	mockCont.Services[provider.ZopAPIService] = mocks.HTTPService
+	ctx := &gofr.Context{Container: mockCont, Out: terminal.New()}
+
+	testCases := []struct {
+		name      string
+		mockCalls []*gomock.Call
+		expResult []*DeploymentSpace
+		expError  error
+	}{
+		{
+			name: "successful GET call",
+			mockCalls: []*gomock.Call{
+				mocks.HTTPService.EXPECT().Get(ctx, "/cloud-accounts/7/deployment-spaces", nil).
+					Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(
+						`{"data": [{"name": "prod-cluster", "type": "gke", "environment": "prod"}]}`))}, nil),
+			},
+			expResult: []*DeploymentSpace{{Name: "prod-cluster", Type: "gke", Environment: "prod"}},
+		},
+		{
+			name: "error during GET call",
+			mockCalls: []*gomock.Call{
+				mocks.HTTPService.EXPECT().Get(ctx, "/cloud-accounts/7/deployment-spaces", nil).
+					Return(nil, errFailFetch),
+			},
+			expError: errFailFetch,
+		},
+		{
+			name: "unexpected status code",
+			mockCalls: []*gomock.Call{
+				mocks.HTTPService.EXPECT().Get(ctx, "/cloud-accounts/7/deployment-spaces", nil).
+					Return(&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(&bytes.Buffer{})}, nil),
+			},
+			expError: &provider.ErrAPIService{StatusCode: http.StatusInternalServerError, Message: "unable to fetch the deployment spaces"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New().GetDeploymentSpaces(ctx, 7)
+
+			require.Equal(t, tt.expError, err)
+			require.Equal(t, tt.expResult, result)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package aws
+
+// Profile stores the settings of a named AWS profile, merged from the shared credentials
+// file (~/.aws/credentials) and the shared config file (~/.aws/config).
+type Profile struct {
+	// Name is the name of the profile, "default" for the default profile.
+	Name string
+
+	// AccessKeyID and SecretAccessKey are the static keys of the profile, if any.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is the session token for temporary static credentials.
+	SessionToken string
+
+	// Region is the default region configured for the profile.
+	Region string
+
+	// RoleARN is the role assumed by the profile using the credentials of SourceProfile
+	// or CredentialSource.
+	RoleARN          string
+	SourceProfile    string
+	CredentialSource string
+	ExternalID       string
+	RoleSessionName  string
+
+	// SSOStartURL, SSORegion, SSOAccountID and SSORoleName describe an IAM Identity Center (SSO) profile.
+	// When the profile refers to an sso-session section, the start url and region are taken from it.
+	SSOStartURL  string
+	SSORegion    string
+	SSOAccountID string
+	SSORoleName  string
+}
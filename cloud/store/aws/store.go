@@ -0,0 +1,185 @@
+// Package aws reads the named profiles configured for the AWS CLI from the shared credentials
+// file at ~/.aws/credentials and the shared config file at ~/.aws/config.
+package aws
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+)
+
+const (
+	profilePrefix    = "profile "
+	ssoSessionPrefix = "sso-session "
+)
+
+// section holds the key value pairs of a single section of an ini file.
+type section map[string]string
+
+// Store reads the AWS profiles from the shared credentials and config files.
+type Store struct {
+	credentialsPath string
+	configPath      string
+}
+
+// New creates a new Store to read the AWS profiles from the given credentials and config files.
+func New(credentialsPath, configPath string) *Store {
+	return &Store{
+		credentialsPath: credentialsPath,
+		configPath:      configPath,
+	}
+}
+
+// GetProfiles returns all the profiles present in the shared credentials and config files.
+// Settings of a profile present in both files are merged, the credentials file taking precedence.
+// Missing files are treated as empty.
+func (s *Store) GetProfiles(ctx *gofr.Context) ([]Profile, error) {
+	creds, err := readINI(s.credentialsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := readINI(s.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]section)
+	ssoSessions := make(map[string]section)
+
+	for name, values := range conf {
+		switch {
+		case strings.HasPrefix(name, ssoSessionPrefix):
+			ssoSessions[strings.TrimSpace(strings.TrimPrefix(name, ssoSessionPrefix))] = values
+		case strings.HasPrefix(name, profilePrefix):
+			mergeSection(merged, strings.TrimSpace(strings.TrimPrefix(name, profilePrefix)), values)
+		case name == "default":
+			mergeSection(merged, name, values)
+		default:
+			ctx.Logger.Debugf("skipping unknown section %q in %s", name, s.configPath)
+		}
+	}
+
+	for name, values := range creds {
+		mergeSection(merged, name, values)
+	}
+
+	profiles := make([]Profile, 0, len(merged))
+
+	for name, values := range merged {
+		profiles = append(profiles, newProfile(name, values, ssoSessions))
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	return profiles, nil
+}
+
+func mergeSection(merged map[string]section, name string, values section) {
+	if _, ok := merged[name]; !ok {
+		merged[name] = make(section)
+	}
+
+	for k, v := range values {
+		merged[name][k] = v
+	}
+}
+
+func newProfile(name string, values section, ssoSessions map[string]section) Profile {
+	p := Profile{
+		Name:             name,
+		AccessKeyID:      values["aws_access_key_id"],
+		SecretAccessKey:  values["aws_secret_access_key"],
+		SessionToken:     values["aws_session_token"],
+		Region:           values["region"],
+		RoleARN:          values["role_arn"],
+		SourceProfile:    values["source_profile"],
+		CredentialSource: values["credential_source"],
+		ExternalID:       values["external_id"],
+		RoleSessionName:  values["role_session_name"],
+		SSOStartURL:      values["sso_start_url"],
+		SSORegion:        values["sso_region"],
+		SSOAccountID:     values["sso_account_id"],
+		SSORoleName:      values["sso_role_name"],
+	}
+
+	if session, ok := ssoSessions[values["sso_session"]]; ok {
+		p.SSOStartURL = session["sso_start_url"]
+		p.SSORegion = session["sso_region"]
+	}
+
+	return p
+}
+
+// readINI parses the ini file at path into its sections. Comments, blank lines and
+// nested settings (indented lines following a key without a value) are ignored.
+func readINI(path string) (map[string]section, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]section{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var (
+		sections = make(map[string]section)
+		current  section
+		nested   bool
+	)
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if nested && (raw[0] == ' ' || raw[0] == '\t') {
+			continue
+		}
+
+		nested = false
+
+		if line[0] == '[' && line[len(line)-1] == ']' {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+
+			if _, ok := sections[name]; !ok {
+				sections[name] = make(section)
+			}
+
+			current = sections[name]
+
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found || current == nil {
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if value == "" {
+			nested = true
+
+			continue
+		}
+
+		current[key] = value
+	}
+
+	return sections, scanner.Err()
+}
@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func TestStore_GetProfiles(t *testing.T) {
+	ctx := &gofr.Context{Container: &container.Container{Logger: logging.NewMockLogger(logging.INFO)}}
+
+	testCases := []struct {
+		name        string
+		credentials string
+		config      string
+		expected    []Profile
+	}{
+		{
+			name:        "credentials and config merged",
+			credentials: filepath.Join("testdata", "credentials"),
+			config:      filepath.Join("testdata", "config"),
+			expected: []Profile{
+				{Name: "admin", RoleARN: "arn:aws:iam::123456789012:role/admin", SourceProfile: "base", Region: "eu-west-1"},
+				{Name: "base", AccessKeyID: "AKIABASE", SecretAccessKey: "base-secret"},
+				{Name: "default", AccessKeyID: "AKIADEFAULT", SecretAccessKey: "default-secret", Region: "us-east-1"},
+				{Name: "dev-sso", SSOStartURL: "https://corp.awsapps.com/start", SSORegion: "us-east-2",
+					SSOAccountID: "210987654321", SSORoleName: "Developer"},
+			},
+		},
+		{
+			name:        "missing files",
+			credentials: filepath.Join("testdata", "missing"),
+			config:      filepath.Join("testdata", "missing"),
+			expected:    []Profile{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profiles, err := New(tc.credentials, tc.config).GetProfiles(ctx)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, profiles)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package azure
+
+// Subscription stores a subscription the az cli is logged in to, as listed in azureProfile.json.
+type Subscription struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	State           string `json:"state"`
+	TenantID        string `json:"tenantId"`
+	IsDefault       bool   `json:"isDefault"`
+	EnvironmentName string `json:"environmentName"`
+	User            User   `json:"user"`
+}
+
+// User is the identity used by the az cli to access a subscription.
+// Type is either "user" or "servicePrincipal", for service principals Name holds the client id.
+type User struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ServicePrincipal stores the credentials of a service principal the az cli logged in with.
+// Certificate holds the PEM contents of the certificate at CertificatePath, if it could be read.
+type ServicePrincipal struct {
+	ClientID        string
+	TenantID        string
+	ClientSecret    string
+	CertificatePath string
+	Certificate     string
+}
+
+// servicePrincipalEntry is an entry of service_principal_entries.json. The az cli has used both the
+// MSAL (client_id, tenant) and the older ADAL (servicePrincipalId, servicePrincipalTenant) key names.
+type servicePrincipalEntry struct {
+	ClientID          string `json:"client_id"`
+	Tenant            string `json:"tenant"`
+	ClientSecret      string `json:"client_secret"`
+	ClientCertificate string `json:"client_certificate"`
+
+	ServicePrincipalID     string `json:"servicePrincipalId"`
+	ServicePrincipalTenant string `json:"servicePrincipalTenant"`
+	AccessToken            string `json:"accessToken"`
+	CertificateFile        string `json:"certificateFile"`
+}
@@ -0,0 +1,108 @@
+// Package azure reads the subscriptions and service principal credentials the az cli keeps
+// in its configuration directory, ~/.azure by default.
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gofr.dev/pkg/gofr"
+)
+
+const (
+	profileFile           = "azureProfile.json"
+	servicePrincipalsFile = "service_principal_entries.json"
+)
+
+// Store reads the az cli configuration files from the given directory.
+type Store struct {
+	configDir string
+}
+
+// New creates a new Store to read the az cli configuration from configDir.
+func New(configDir string) *Store {
+	return &Store{
+		configDir: configDir,
+	}
+}
+
+// GetSubscriptions returns the subscriptions listed in azureProfile.json.
+// A missing file is treated as no subscriptions.
+func (s *Store) GetSubscriptions(*gofr.Context) ([]Subscription, error) {
+	var profile struct {
+		Subscriptions []Subscription `json:"subscriptions"`
+	}
+
+	if err := readJSON(filepath.Join(s.configDir, profileFile), &profile); err != nil {
+		return nil, err
+	}
+
+	return profile.Subscriptions, nil
+}
+
+// GetServicePrincipals returns the service principal credentials stored by `az login --service-principal`.
+// A missing file is treated as no service principals.
+func (s *Store) GetServicePrincipals(ctx *gofr.Context) ([]ServicePrincipal, error) {
+	var entries []servicePrincipalEntry
+
+	if err := readJSON(filepath.Join(s.configDir, servicePrincipalsFile), &entries); err != nil {
+		return nil, err
+	}
+
+	sps := make([]ServicePrincipal, 0, len(entries))
+
+	for i := range entries {
+		e := &entries[i]
+
+		sp := ServicePrincipal{
+			ClientID:        firstNonEmpty(e.ClientID, e.ServicePrincipalID),
+			TenantID:        firstNonEmpty(e.Tenant, e.ServicePrincipalTenant),
+			ClientSecret:    firstNonEmpty(e.ClientSecret, e.AccessToken),
+			CertificatePath: firstNonEmpty(e.ClientCertificate, e.CertificateFile),
+		}
+
+		if sp.CertificatePath != "" {
+			cert, err := os.ReadFile(sp.CertificatePath)
+			if err != nil {
+				ctx.Logger.Errorf("unable to read certificate of service principal %s: %v", sp.ClientID, err)
+			}
+
+			sp.Certificate = string(cert)
+		}
+
+		sps = append(sps, sp)
+	}
+
+	return sps, nil
+}
+
+// readJSON unmarshals the file at path into v, it leaves v untouched if the file does not exist.
+func readJSON(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The az cli writes its json files with a UTF-8 byte order mark.
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+
+	return json.Unmarshal(b, v)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,40 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func TestStore_GetSubscriptions(t *testing.T) {
+	subs, err := New("testdata").GetSubscriptions(&gofr.Context{})
+
+	require.NoError(t, err)
+	require.Equal(t, []Subscription{
+		{ID: "sub-1", Name: "Payments", State: "Enabled", TenantID: "tenant-1", IsDefault: true,
+			EnvironmentName: "AzureCloud", User: User{Name: "dev@example.com", Type: "user"}},
+		{ID: "sub-2", Name: "Platform", State: "Enabled", TenantID: "tenant-2",
+			EnvironmentName: "AzureCloud", User: User{Name: "client-2", Type: "servicePrincipal"}},
+	}, subs)
+
+	subs, err = New("missing").GetSubscriptions(&gofr.Context{})
+
+	require.NoError(t, err)
+	require.Empty(t, subs)
+}
+
+func TestStore_GetServicePrincipals(t *testing.T) {
+	ctx := &gofr.Context{Container: &container.Container{Logger: logging.NewMockLogger(logging.INFO)}}
+
+	sps, err := New("testdata").GetServicePrincipals(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, []ServicePrincipal{
+		{ClientID: "client-2", TenantID: "tenant-2", ClientSecret: "secret-2"},
+		{ClientID: "client-3", TenantID: "tenant-3", CertificatePath: "testdata/sp.pem",
+			Certificate: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"},
+	}, sps)
+}
@@ -1,35 +1,157 @@
-// Package gcp implements the AccountGetter interface to fetch all accounts from the gcloud database
-// at ~/.config/gcloud/credentials.db, reading the account_id and value from the database
+// Package gcp implements the AccountStore interface to fetch the gcloud accounts and service account keys
+// available locally. They are read from the key files given with -key-file, or else from the file at
+// GOOGLE_APPLICATION_CREDENTIALS, the gcloud database at ~/.config/gcloud/credentials.db and the
+// application default credentials of gcloud.
 package gcp
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	// sqlite3 is the driver of the gcloud credentials database.
+	_ "github.com/mattn/go-sqlite3"
 	"gofr.dev/pkg/gofr"
 )
 
-// Store is a struct that holds the database connection and implements the AccountGetter interface.
+const (
+	credentialsDB       = "credentials.db"
+	applicationDefaults = "application_default_credentials.json"
+	legacyCredentials   = "legacy_credentials"
+	legacyADC           = "adc.json"
+
+	// applicationDefaultAccount is the account id of application default credentials of a user,
+	// which do not hold the email of the account.
+	applicationDefaultAccount = "application-default"
+)
+
+// ErrInvalidKeyFile is returned when a credentials file is neither a service account key nor user credentials.
+var ErrInvalidKeyFile = errors.New("invalid gcloud credentials file")
+
+// Store reads the gcloud accounts from the gcloud configuration directory and credential files.
 type Store struct {
-	db *sql.DB
+	configDir              string
+	applicationCredentials string
 }
 
-// New creates a new Store struct to fetch all accounts from the gcloud database.
-func New(db *sql.DB) *Store {
+// New creates a new Store to fetch the accounts from the gcloud configuration directory configDir,
+// ~/.config/gcloud by default, and the credentials file applicationCredentials set with
+// GOOGLE_APPLICATION_CREDENTIALS, which may be empty.
+func New(configDir, applicationCredentials string) *Store {
 	return &Store{
-		db: db,
+		configDir:              configDir,
+		applicationCredentials: applicationCredentials,
 	}
 }
 
-// GetAccounts returns a list of accounts from the database reading the account_id and value
-// from the gcloud database at ~/.config/gcloud/credentials.db.
+// credentialsFile holds the fields of a credentials file needed to identify its account.
+type credentialsFile struct {
+	Type        string `json:"type"`
+	ClientEmail string `json:"client_email"`
+}
+
+// GetAccounts returns the accounts of the key files given with -key-file. Without -key-file it returns
+// the accounts of GOOGLE_APPLICATION_CREDENTIALS, the gcloud database and the application default
+// credentials, an account found in more than one of them is only returned once.
 func (s *Store) GetAccounts(ctx *gofr.Context) ([]AccountStore, error) {
+	if keyFiles := keyFiles(ctx); len(keyFiles) != 0 {
+		return readFiles(keyFiles)
+	}
+
 	ans := make([]AccountStore, 0)
 
-	rows, err := s.db.QueryContext(ctx, "SELECT account_id, value FROM credentials")
+	if s.applicationCredentials != "" {
+		acc, err := readFile(s.applicationCredentials, "")
+		if err != nil {
+			return nil, err
+		}
+
+		ans = append(ans, acc)
+	}
+
+	dbAccounts, err := s.getDBAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ans = append(ans, dbAccounts...)
+
+	if acc, er := readFile(filepath.Join(s.configDir, applicationDefaults), applicationDefaultAccount); er == nil {
+		ans = append(ans, acc)
+	} else if !errors.Is(er, os.ErrNotExist) {
+		return nil, er
+	}
+
+	legacy, err := filepath.Glob(filepath.Join(s.configDir, legacyCredentials, "*", legacyADC))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range legacy {
+		acc, er := readFile(path, filepath.Base(filepath.Dir(path)))
+		if er != nil {
+			return nil, er
+		}
+
+		ans = append(ans, acc)
+	}
+
+	return unique(ans), nil
+}
+
+// Sources returns the credential sources GetAccounts reads from that are present.
+func (s *Store) Sources(ctx *gofr.Context) []string {
+	if keyFiles := keyFiles(ctx); len(keyFiles) != 0 {
+		return keyFiles
+	}
+
+	candidates := []string{
+		s.applicationCredentials,
+		filepath.Join(s.configDir, credentialsDB),
+		filepath.Join(s.configDir, applicationDefaults),
+	}
+
+	legacy, _ := filepath.Glob(filepath.Join(s.configDir, legacyCredentials, "*", legacyADC))
+
+	sources := make([]string, 0, len(candidates)+len(legacy))
+
+	for _, path := range append(candidates, legacy...) {
+		if _, err := os.Stat(path); path != "" && err == nil {
+			sources = append(sources, path)
+		}
+	}
+
+	return sources
+}
+
+// getDBAccounts reads the account_id and value of the accounts in the gcloud database, if there is one.
+func (s *Store) getDBAccounts(ctx *gofr.Context) ([]AccountStore, error) {
+	path := filepath.Join(s.configDir, credentialsDB)
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, "SELECT account_id, value FROM credentials")
 	if err != nil || rows.Err() != nil {
 		return nil, err
 	}
 
+	defer rows.Close()
+
+	ans := make([]AccountStore, 0)
+
 	for rows.Next() {
 		var acc AccountStore
 
@@ -43,3 +165,73 @@ func (s *Store) GetAccounts(ctx *gofr.Context) ([]AccountStore, error) {
 
 	return ans, nil
 }
+
+// keyFiles returns the paths of the comma separated -key-file flag.
+func keyFiles(ctx *gofr.Context) []string {
+	var paths []string
+
+	for _, path := range strings.Split(ctx.Param("key-file"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+func readFiles(paths []string) ([]AccountStore, error) {
+	ans := make([]AccountStore, 0, len(paths))
+
+	for _, path := range paths {
+		acc, err := readFile(path, "")
+		if err != nil {
+			return nil, err
+		}
+
+		ans = append(ans, acc)
+	}
+
+	return unique(ans), nil
+}
+
+// readFile reads a credentials file. The account id of a service account key is its email,
+// other credentials get accountID, or the file name if it is empty.
+func readFile(path, accountID string) (AccountStore, error) {
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return AccountStore{}, err
+	}
+
+	var creds credentialsFile
+
+	if err = json.Unmarshal(value, &creds); err != nil || creds.Type == "" {
+		return AccountStore{}, fmt.Errorf("%w: %s", ErrInvalidKeyFile, path)
+	}
+
+	switch {
+	case creds.ClientEmail != "":
+		accountID = creds.ClientEmail
+	case accountID == "":
+		accountID = filepath.Base(path)
+	}
+
+	return AccountStore{AccountID: accountID, Value: value}, nil
+}
+
+// unique removes the accounts whose account id was already seen, keeping the first one.
+func unique(accounts []AccountStore) []AccountStore {
+	seen := make(map[string]bool, len(accounts))
+	ans := accounts[:0]
+
+	for _, acc := range accounts {
+		if seen[acc.AccountID] {
+			continue
+		}
+
+		seen[acc.AccountID] = true
+
+		ans = append(ans, acc)
+	}
+
+	return ans
+}
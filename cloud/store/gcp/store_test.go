@@ -0,0 +1,90 @@
+package gcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+)
+
+func accountIDs(accounts []AccountStore) []string {
+	ids := make([]string, 0, len(accounts))
+
+	for _, acc := range accounts {
+		ids = append(ids, acc.AccountID)
+	}
+
+	return ids
+}
+
+func TestStore_GetAccounts(t *testing.T) {
+	key := filepath.Join("testdata", "key.json")
+	configDir := filepath.Join("testdata", "gcloud")
+
+	testCases := []struct {
+		name                   string
+		args                   []string
+		applicationCredentials string
+		expected               []string
+		expErr                 error
+	}{
+		{
+			name:     "application default credentials",
+			args:     []string{""},
+			expected: []string{"application-default", "ci@payments.iam.gserviceaccount.com", "dev@example.com"},
+		},
+		{
+			name:                   "GOOGLE_APPLICATION_CREDENTIALS first",
+			args:                   []string{""},
+			applicationCredentials: key,
+			expected:               []string{"ci@payments.iam.gserviceaccount.com", "application-default", "dev@example.com"},
+		},
+		{
+			name:     "key files only",
+			args:     []string{"", "-key-file=" + key + ", " + key},
+			expected: []string{"ci@payments.iam.gserviceaccount.com"},
+		},
+		{
+			name:   "missing key file",
+			args:   []string{"", "-key-file=" + filepath.Join("testdata", "missing.json")},
+			expErr: os.ErrNotExist,
+		},
+		{
+			name:   "invalid key file",
+			args:   []string{"", "-key-file=" + filepath.Join("testdata", "invalid.json")},
+			expErr: ErrInvalidKeyFile,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := &gofr.Context{Request: cmd.NewRequest(tc.args)}
+
+			accounts, err := New(configDir, tc.applicationCredentials).GetAccounts(ctx)
+
+			require.ErrorIs(t, err, tc.expErr)
+
+			if tc.expErr == nil {
+				require.Equal(t, tc.expected, accountIDs(accounts))
+			}
+		})
+	}
+}
+
+func TestStore_Sources(t *testing.T) {
+	configDir := filepath.Join("testdata", "gcloud")
+
+	require.Equal(t, []string{
+		filepath.Join(configDir, "application_default_credentials.json"),
+		filepath.Join(configDir, "legacy_credentials", "ci@payments.iam.gserviceaccount.com", "adc.json"),
+		filepath.Join(configDir, "legacy_credentials", "dev@example.com", "adc.json"),
+	}, New(configDir, "").Sources(&gofr.Context{Request: cmd.NewRequest([]string{""})}))
+
+	require.Equal(t, []string{"key.json"},
+		New(configDir, "").Sources(&gofr.Context{Request: cmd.NewRequest([]string{"", "-key-file=key.json"})}))
+
+	require.Empty(t, New(t.TempDir(), "").Sources(&gofr.Context{Request: cmd.NewRequest([]string{""})}))
+}
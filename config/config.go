@@ -0,0 +1,45 @@
+// Package config reads the zop cli configuration file, ~/.zop/config.json by default.
+// The file is optional, a missing file results in an empty configuration.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Config is the configuration of the zop cli.
+type Config struct {
+	// DefaultRoleProfile is the role profile used for new GCP service accounts when -role-profile is not set.
+	DefaultRoleProfile string `json:"defaultRoleProfile,omitempty"`
+
+	// RoleProfiles are named sets of roles granted to new GCP service accounts,
+	// they are added to the built-in profiles and override built-in profiles of the same name.
+	RoleProfiles map[string][]string `json:"roleProfiles,omitempty"`
+
+	// APIServiceAccount is the email of the GCP service account zop api runs as. Service accounts imported
+	// in keyless mode allow it to impersonate them instead of handing a key to zop api.
+	APIServiceAccount string `json:"apiServiceAccount,omitempty"`
+}
+
+// Load reads the configuration file at path.
+func Load(path string) (*Config, error) {
+	var cfg Config
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &cfg, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid zop config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
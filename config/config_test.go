@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(valid,
+		[]byte(`{"defaultRoleProfile": "custom", "roleProfiles": {"custom": ["roles/container.developer"]}}`), 0o600))
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"roleProfiles": [`), 0o600))
+
+	cfg, err := Load(valid)
+
+	require.NoError(t, err)
+	require.Equal(t, &Config{
+		DefaultRoleProfile: "custom",
+		RoleProfiles:       map[string][]string{"custom": {"roles/container.developer"}},
+	}, cfg)
+
+	cfg, err = Load(filepath.Join(dir, "missing.json"))
+
+	require.NoError(t, err)
+	require.Equal(t, &Config{}, cfg)
+
+	_, err = Load(invalid)
+
+	require.Error(t, err)
+}
@@ -2,15 +2,14 @@
 package handler
 
 import (
-	"bytes"
 	"fmt"
-	"sort"
-	"text/tabwriter"
+	"strconv"
 
 	"gofr.dev/pkg/gofr"
-)
 
-const padding = 2
+	"zop.dev/cli/zop/environment/service"
+	"zop.dev/cli/zop/utils"
+)
 
 // Handler is responsible for managing environment-related operations.
 type Handler struct {
@@ -40,34 +39,44 @@ func (h *Handler) Add(ctx *gofr.Context) (any, error) {
 	return fmt.Sprintf("%d environments added", n), nil
 }
 
+// List lists the environments of the application given with -application-id or -app, or selected from a list,
+// as a table or in the format selected with -output.
+// Environments are ordered by ID unless -sort-by is set. With -watch the environments are fetched and redrawn
+// every -interval, the application is selected once.
 func (h *Handler) List(ctx *gofr.Context) (any, error) {
-	envs, err := h.envSvc.List(ctx)
+	out, err := utils.GetOutput(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Slice(envs, func(i, j int) bool { return envs[i].ID < envs[j].ID })
-
-	b := bytes.NewBuffer([]byte{})
+	app, err := h.envSvc.SelectApplication(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	// Print a table of all the environments in the application
-	writer := tabwriter.NewWriter(b, 0, 0, padding, ' ', tabwriter.Debug)
+	// Only tables are meant for people, other formats are parsed by scripts.
+	if out.Format == utils.OutputTable {
+		ctx.Out.Println("Selected application: ", app.Name)
+	}
 
-	// Print table headers
-	fmt.Fprintln(writer, "Name\tLevel\tCreatedAt\tUpdatedAt")
+	out.SortByDefault("id")
 
-	// Print rows for each environment
-	for _, env := range envs {
-		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\n",
-			env.Name,
-			env.Level,
-			env.CreatedAt,
-			env.UpdatedAt,
-		)
-	}
+	return utils.Watch(ctx, func() (any, error) {
+		envs, err := h.envSvc.GetEnvironments(ctx, app.ID)
+		if err != nil {
+			return nil, err
+		}
 
-	// Flush the writer to output the table
-	writer.Flush()
+		if envs, err = utils.Select(out, envs); err != nil {
+			return "", err
+		}
 
-	return b.String(), nil
+		return utils.Render(out, envs, []utils.Column[service.Environment]{
+			{Header: "ID", Value: func(env service.Environment) string { return strconv.FormatInt(env.ID, 10) }, Wide: true},
+			{Header: "Name", Value: func(env service.Environment) string { return env.Name }},
+			{Header: "Level", Value: func(env service.Environment) string { return strconv.Itoa(env.Level) }},
+			{Header: "Created At", Value: func(env service.Environment) string { return out.FormatTime(env.CreatedAt) }},
+			{Header: "Updated At", Value: func(env service.Environment) string { return out.FormatTime(env.UpdatedAt) }},
+		})
+	})
 }
@@ -4,9 +4,11 @@ import (
 	"gofr.dev/pkg/gofr"
 
 	"zop.dev/cli/zop/environment/service"
+	"zop.dev/cli/zop/utils"
 )
 
 type EnvironmentService interface {
 	Add(ctx *gofr.Context) (int, error)
-	List(ctx *gofr.Context) ([]service.Environment, error)
+	SelectApplication(ctx *gofr.Context) (*utils.Item, error)
+	GetEnvironments(ctx *gofr.Context, appID int64) ([]service.Environment, error)
 }
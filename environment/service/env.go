@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"gofr.dev/pkg/gofr"
 
@@ -24,6 +25,12 @@ var (
 	// ErrorAddingEnv is returned when there is an error adding an environment.
 	ErrorAddingEnv = errors.New("unable to add environment")
 
+	// ErrApplicationNotFound is returned when no application matches -application-id or -app.
+	ErrApplicationNotFound = errors.New("application not found")
+
+	// ErrInvalidApplicationID is returned when -application-id is not a number.
+	ErrInvalidApplicationID = errors.New("invalid -application-id, expected the numeric id of the application")
+
 	// ErrNoApplicationSelected is returned when no application is selected.
 	ErrNoApplicationSelected = errors.New("no application selected")
 
@@ -44,7 +51,7 @@ func New(appGet ApplicationGetter) *Service {
 // Add prompts the user to add environments to a selected application.
 // It returns the number of environments added and an error, if any.
 func (s *Service) Add(ctx *gofr.Context) (int, error) {
-	app, err := s.getSelectedApplication(ctx)
+	app, err := s.SelectApplication(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -82,16 +89,20 @@ func (s *Service) Add(ctx *gofr.Context) (int, error) {
 	return level, nil
 }
 
+// List fetches the environments of the selected application.
 func (s *Service) List(ctx *gofr.Context) ([]Environment, error) {
-	app, err := s.getSelectedApplication(ctx)
+	app, err := s.SelectApplication(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx.Out.Println("Selected application: ", app.Name)
+	return s.GetEnvironments(ctx, app.ID)
+}
 
+// GetEnvironments fetches the environments of the application with the given ID.
+func (*Service) GetEnvironments(ctx *gofr.Context, appID int64) ([]Environment, error) {
 	resp, err := ctx.GetHTTPService("api-service").
-		Get(ctx, fmt.Sprintf("applications/%d/environments", app.ID), nil)
+		Get(ctx, fmt.Sprintf("applications/%d/environments", appID), nil)
 	if err != nil {
 		ctx.Logger.Errorf("unable to connect to Zop API! %v", err)
 
@@ -112,9 +123,10 @@ func (s *Service) List(ctx *gofr.Context) ([]Environment, error) {
 	return data.Envs, nil
 }
 
-// getSelectedApplication renders a list of applications for the user to select from.
+// SelectApplication returns the application given with -application-id or -app, so that scripts can use the
+// command, or else renders a list of applications for the user to select from.
 // It returns the selected application or an error if no selection is made.
-func (s *Service) getSelectedApplication(ctx *gofr.Context) (*utils.Item, error) {
+func (s *Service) SelectApplication(ctx *gofr.Context) (*utils.Item, error) {
 	apps, err := s.appGet.List(ctx)
 	if err != nil {
 		return nil, err
@@ -126,6 +138,10 @@ func (s *Service) getSelectedApplication(ctx *gofr.Context) (*utils.Item, error)
 		items = append(items, &utils.Item{ID: app.ID, Name: app.Name})
 	}
 
+	if id, name := ctx.Param("application-id"), ctx.Param("app"); id != "" || name != "" {
+		return findApplication(items, id, name)
+	}
+
 	choice, err := utils.RenderList(listTitle, items)
 	if err != nil {
 		ctx.Logger.Errorf("unable to render the list of applications! %v", err)
@@ -140,6 +156,31 @@ func (s *Service) getSelectedApplication(ctx *gofr.Context) (*utils.Item, error)
 	return choice, nil
 }
 
+// findApplication returns the application with the given id, or with the given name when id is empty.
+func findApplication(items []*utils.Item, id, name string) (*utils.Item, error) {
+	var appID int64
+
+	if id != "" {
+		var err error
+
+		if appID, err = strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, ErrInvalidApplicationID
+		}
+	}
+
+	for _, item := range items {
+		if (id != "" && item.ID == appID) || (id == "" && item.Name == name) {
+			return item, nil
+		}
+	}
+
+	if id != "" {
+		return nil, fmt.Errorf("%w: %s", ErrApplicationNotFound, id)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrApplicationNotFound, name)
+}
+
 // postEnvironment sends a POST request to the API to add the provided environment to the application.
 // It returns an error if the request fails or the response status code is not created (201).
 func postEnvironment(ctx *gofr.Context, env *Environment) error {
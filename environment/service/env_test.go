@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zop.dev/cli/zop/utils"
+)
+
+func Test_findApplication(t *testing.T) {
+	items := []*utils.Item{{ID: 1, Name: "payments"}, {ID: 2, Name: "search"}}
+
+	testCases := []struct {
+		name     string
+		id       string
+		app      string
+		expected *utils.Item
+		err      error
+	}{
+		{name: "by id", id: "2", expected: items[1]},
+		{name: "by name", app: "payments", expected: items[0]},
+		{name: "id before name", id: "1", app: "search", expected: items[0]},
+		{name: "unknown id", id: "3", err: ErrApplicationNotFound},
+		{name: "unknown name", app: "billing", err: ErrApplicationNotFound},
+		{name: "invalid id", id: "payments", err: ErrInvalidApplicationID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			item, err := findApplication(items, tc.id, tc.app)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, item)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package service
 
+import "zop.dev/cli/zop/utils"
+
 // Environment represents an environment within an application.
 // It holds details about the environment, such as its ID, associated application ID,
 // level, name, and timestamps for when it was created, updated, and optionally deleted.
@@ -17,8 +19,8 @@ type Environment struct {
 	Name string `json:"name"`
 
 	// CreatedAt is the timestamp of when the environment was created.
-	CreatedAt string `json:"createdAt"`
+	CreatedAt utils.Timestamp `json:"createdAt"`
 
 	// UpdatedAt is the timestamp of when the environment was last updated.
-	UpdatedAt string `json:"updatedAt"`
+	UpdatedAt utils.Timestamp `json:"updatedAt"`
 }
@@ -1,21 +1,24 @@
 package main
 
 import (
-	"database/sql"
-	"log"
 	"os"
 	"path/filepath"
 
-	_ "github.com/mattn/go-sqlite3"
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/service"
 
 	applicationHandler "zop.dev/cli/zop/application/handler"
 	applicationSvc "zop.dev/cli/zop/application/service"
 	impHandler "zop.dev/cli/zop/cloud/handler"
+	"zop.dev/cli/zop/cloud/provider"
+	awsService "zop.dev/cli/zop/cloud/service/aws"
+	azureService "zop.dev/cli/zop/cloud/service/azure"
 	impService "zop.dev/cli/zop/cloud/service/gcp"
 	listSvc "zop.dev/cli/zop/cloud/service/list"
+	awsStore "zop.dev/cli/zop/cloud/store/aws"
+	azureStore "zop.dev/cli/zop/cloud/store/azure"
 	impStore "zop.dev/cli/zop/cloud/store/gcp"
+	"zop.dev/cli/zop/config"
 	depHandler "zop.dev/cli/zop/deploymentspace/handler"
 	depSvc "zop.dev/cli/zop/deploymentspace/service"
 	envHandler "zop.dev/cli/zop/environment/handler"
@@ -30,7 +33,7 @@ const (
 func main() {
 	app := gofr.NewCMD()
 
-	app.AddHTTPService(impService.ZopAPIService, app.Config.Get("ZOP_API_URL"))
+	app.AddHTTPService(provider.ZopAPIService, app.Config.Get("ZOP_API_URL"))
 	app.AddHTTPService(impService.GcloudService, tokenURL,
 		&service.DefaultHeaders{Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"}})
 
@@ -39,22 +42,44 @@ func main() {
 		app.Logger().Fatalf("Failed to get the user's home directory: %v", err)
 	}
 
-	// Build the path to the credentials.db file of gcloud
-	dbPath := filepath.Join(homeDir, ".config", "gcloud", "credentials.db")
+	// Build the path to the configuration directory of gcloud, holding credentials.db and the application default credentials
+	gcloudConfigDir := app.Config.GetOrDefault("CLOUDSDK_CONFIG", filepath.Join(homeDir, ".config", "gcloud"))
+	gcpAccounts := impStore.New(gcloudConfigDir, app.Config.Get("GOOGLE_APPLICATION_CREDENTIALS"))
 
-	db, err := sql.Open("sqlite3", dbPath)
+	// Build the paths to the shared credentials and config files of the aws cli
+	awsCredentialsPath := app.Config.GetOrDefault("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(homeDir, ".aws", "credentials"))
+	awsConfigPath := app.Config.GetOrDefault("AWS_CONFIG_FILE", filepath.Join(homeDir, ".aws", "config"))
+
+	// Build the path to the configuration directory of the az cli
+	azureConfigDir := app.Config.GetOrDefault("AZURE_CONFIG_DIR", filepath.Join(homeDir, ".azure"))
+
+	// Build the path to the zop config file
+	cfg, err := config.Load(app.Config.GetOrDefault("ZOP_CONFIG", filepath.Join(homeDir, ".zop", "config.json")))
 	if err != nil {
-		log.Fatalf("Failed to open the database: %v", err)
+		app.Logger().Fatalf("Failed to read the zop config file: %v", err)
 	}
-	defer db.Close()
 
-	accStore := impStore.New(db)
-	accSvc := impService.New(accStore)
 	lSvc := listSvc.New()
-	h := impHandler.New(accSvc, lSvc)
+	providers := provider.NewRegistry()
+
+	err = providers.Register(
+		impService.NewProvider(gcpAccounts, lSvc, cfg, gcpAccounts.Sources),
+		awsService.NewProvider(awsStore.New(awsCredentialsPath, awsConfigPath), awsCredentialsPath, awsConfigPath),
+		azureService.NewProvider(azureStore.New(azureConfigDir), azureConfigDir),
+	)
+	if err != nil {
+		app.Logger().Fatalf("Failed to register the cloud providers: %v", err)
+	}
+
+	gcpSvc := impService.New(gcpAccounts, lSvc, cfg)
+	h := impHandler.New(providers, lSvc, gcpSvc, gcpSvc, gcpSvc, gcpSvc)
 
 	app.SubCommand("cloud import", h.Import)
 	app.SubCommand("cloud list", h.List)
+	app.SubCommand("cloud rotate-keys", h.RotateKeys)
+	app.SubCommand("cloud show", h.Show)
+	app.SubCommand("cloud remove", h.Remove)
+	app.SubCommand("cloud verify", h.Verify)
 
 	appSvc := applicationSvc.New()
 	appH := applicationHandler.New(appSvc)
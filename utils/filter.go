@@ -0,0 +1,269 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operators of -filter terms, like provider=gcp or name~=payments*. The flag parser of gofr drops flag values
+// holding a "=", so on the command line a colon stands in for it: provider:gcp, name!:search or name~:payments*.
+const (
+	opEqual    = "="
+	opNotEqual = "!="
+	opMatch    = "~="
+)
+
+var (
+	// ErrInvalidFilter is returned when a -filter term is not of the form <field><operator><value>.
+	ErrInvalidFilter = errors.New("invalid filter, expected <field>=<value>, <field>!=<value> or <field>~=<glob>, " +
+		"written as <field>:<value>, <field>!:<value> or <field>~:<glob> on the command line")
+
+	// ErrUnknownField is returned when -filter or -sort-by name a field the listed resources do not have.
+	ErrUnknownField = errors.New("unknown field")
+)
+
+// filterTerm is a single term of -filter, rows match the filter if they match every term.
+type filterTerm struct {
+	field string
+	op    string
+	value string
+}
+
+// parseFilter parses the comma separated terms of -filter, like provider=gcp,name~=payments* or, as given on
+// the command line, provider:gcp,name~:payments*.
+func parseFilter(param string) ([]filterTerm, error) {
+	var terms []filterTerm
+
+	for _, term := range strings.Split(param, ",") {
+		if term = strings.TrimSpace(term); term == "" {
+			continue
+		}
+
+		i := strings.IndexAny(term, "!~=:")
+		if i <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFilter, term)
+		}
+
+		t := filterTerm{field: term[:i], op: opEqual, value: term[i+1:]}
+
+		switch {
+		case strings.HasPrefix(term[i:], opNotEqual), strings.HasPrefix(term[i:], "!:"):
+			t.op, t.value = opNotEqual, term[i+2:]
+		case strings.HasPrefix(term[i:], opMatch), strings.HasPrefix(term[i:], "~:"):
+			t.op, t.value = opMatch, term[i+2:]
+		case term[i] == '!' || term[i] == '~':
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFilter, term)
+		}
+
+		if _, err := path.Match(t.value, ""); t.op == opMatch && err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidFilter, term, err)
+		}
+
+		terms = append(terms, t)
+	}
+
+	return terms, nil
+}
+
+// Select returns the rows matching -filter, in the order of -sort-by. Fields are named like the json fields
+// of the rows, case-insensitively, and nested fields are joined with dots, like providerDetails.region.
+// Without -sort-by the rows are ordered by the default set with SortByDefault, or kept in their order.
+func Select[T any](out *Output, rows []T) ([]T, error) {
+	if len(out.filter) == 0 && out.sortBy == "" {
+		return rows, nil
+	}
+
+	fields := make([]map[string]any, len(rows))
+	known := make(map[string]string)
+
+	for i, row := range rows {
+		b, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(b, &fields[i]); err != nil {
+			return nil, err
+		}
+
+		collectKeys("", fields[i], known)
+	}
+
+	names := make([]string, 0, len(out.filter)+1)
+	for _, t := range out.filter {
+		names = append(names, t.field)
+	}
+
+	if out.sortBy != "" {
+		names = append(names, out.sortBy)
+	}
+
+	if err := checkFields(names, known, len(rows)); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]int, 0, len(rows))
+
+	for i := range rows {
+		if matchesFilter(fields[i], out.filter) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	if out.sortBy != "" {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			x, y := lookup(fields[indexes[a]], out.sortBy), lookup(fields[indexes[b]], out.sortBy)
+			if out.descending {
+				return less(y, x)
+			}
+
+			return less(x, y)
+		})
+	}
+
+	selected := make([]T, len(indexes))
+	for i, index := range indexes {
+		selected[i] = rows[index]
+	}
+
+	return selected, nil
+}
+
+// collectKeys records the dot-joined names of every field of the row, keyed by their normalized name.
+func collectKeys(prefix string, value any, known map[string]string) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+
+	for k, v := range m {
+		name := k
+		if prefix != "" {
+			name = prefix + "." + k
+		}
+
+		known[normalizeField(name)] = name
+
+		collectKeys(name, v, known)
+	}
+}
+
+// normalizeField makes field names match regardless of case, underscores and dashes, so that created_at,
+// like in -columns, names the createdAt field.
+func normalizeField(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// checkFields returns ErrUnknownField for names that are no field of any row. Nothing is checked without rows.
+func checkFields(names []string, known map[string]string, rows int) error {
+	if rows == 0 {
+		return nil
+	}
+
+	for _, name := range names {
+		if _, ok := known[normalizeField(name)]; ok {
+			continue
+		}
+
+		available := make([]string, 0, len(known))
+		for _, k := range known {
+			available = append(available, k)
+		}
+
+		sort.Strings(available)
+
+		return fmt.Errorf("%w %q, available fields: %s", ErrUnknownField, name, strings.Join(available, ", "))
+	}
+
+	return nil
+}
+
+// matchesFilter reports whether the row matches every term of the filter. Values are compared
+// case-insensitively, ~= matches a glob like payments*.
+func matchesFilter(row map[string]any, filter []filterTerm) bool {
+	for _, t := range filter {
+		value := strings.ToLower(stringValue(lookup(row, t.field)))
+		expected := strings.ToLower(t.value)
+
+		var matched bool
+
+		switch t.op {
+		case opMatch:
+			matched, _ = path.Match(expected, value)
+		case opNotEqual:
+			matched = value != expected
+		default:
+			matched = value == expected
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookup returns the field of the row with the given dot-joined name, matching names like normalizeField.
+func lookup(row map[string]any, name string) any {
+	var value any = row
+
+	for _, part := range strings.Split(name, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = nil
+
+		for k, v := range m {
+			if normalizeField(k) == normalizeField(part) {
+				value = v
+
+				break
+			}
+		}
+	}
+
+	return value
+}
+
+// less orders numbers numerically and everything else by its text, case-insensitively.
+func less(x, y any) bool {
+	if a, ok := x.(float64); ok {
+		if b, ok := y.(float64); ok {
+			return a < b
+		}
+	}
+
+	return strings.ToLower(stringValue(x)) < strings.ToLower(stringValue(y))
+}
+
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+
+		return string(b)
+	}
+}
+
+// SortByDefault orders the rows of Select by the field when -sort-by is not set.
+func (o *Output) SortByDefault(field string) {
+	if o.sortBy == "" {
+		o.sortBy = field
+	}
+}
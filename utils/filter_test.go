@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+)
+
+type account struct {
+	Name            string         `json:"name"`
+	Provider        string         `json:"provider"`
+	Level           int            `json:"level"`
+	ProviderDetails map[string]any `json:"providerDetails"`
+}
+
+func accountNames(accounts []account) []string {
+	names := make([]string, len(accounts))
+	for i, a := range accounts {
+		names[i] = a.Name
+	}
+
+	return names
+}
+
+func Test_parseFilter(t *testing.T) {
+	terms, err := parseFilter("provider=gcp, name~=payments*,level!=2,region:us")
+
+	require.NoError(t, err)
+	assert.Equal(t, []filterTerm{
+		{field: "provider", op: opEqual, value: "gcp"},
+		{field: "name", op: opMatch, value: "payments*"},
+		{field: "level", op: opNotEqual, value: "2"},
+		{field: "region", op: opEqual, value: "us"},
+	}, terms)
+
+	for _, filter := range []string{"gcp", "=gcp", "name~payments", "name~=[payments"} {
+		_, err = parseFilter(filter)
+
+		require.ErrorIs(t, err, ErrInvalidFilter, filter)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	accounts := []account{
+		{Name: "payments-prod", Provider: "gcp", Level: 3, ProviderDetails: map[string]any{"region": "us"}},
+		{Name: "search", Provider: "aws", Level: 1, ProviderDetails: map[string]any{"region": "eu"}},
+		{Name: "Payments-dev", Provider: "GCP", Level: 10},
+	}
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected []string
+		err      error
+	}{
+		{name: "no selection", expected: []string{"payments-prod", "search", "Payments-dev"}},
+		{name: "equal, case-insensitive", args: []string{"-filter=provider:gcp"},
+			expected: []string{"payments-prod", "Payments-dev"}},
+		{name: "glob and not equal", args: []string{"-filter=name~:payments*,level!:3"}, expected: []string{"Payments-dev"}},
+		{name: "nested field", args: []string{"-filter=provider_details.region:eu"}, expected: []string{"search"}},
+		{name: "numeric sort", args: []string{"-sort-by=level"}, expected: []string{"search", "payments-prod", "Payments-dev"}},
+		{name: "descending sort", args: []string{"-sort-by=-name"}, expected: []string{"search", "payments-prod", "Payments-dev"}},
+		{name: "unknown field", args: []string{"-sort-by=createdAt"}, err: ErrUnknownField},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := GetOutput(&gofr.Context{Request: cmd.NewRequest(append([]string{""}, tc.args...))})
+			require.NoError(t, err)
+
+			selected, err := Select(out, accounts)
+
+			require.ErrorIs(t, err, tc.err)
+
+			if tc.err == nil {
+				assert.Equal(t, tc.expected, accountNames(selected))
+			}
+		})
+	}
+}
+
+func TestSelect_requestedSyntax(t *testing.T) {
+	accounts := []account{
+		{Name: "payments-prod", Provider: "gcp"},
+		{Name: "search", Provider: "gcp"},
+		{Name: "payments-dev", Provider: "aws"},
+	}
+
+	// The flag parser drops values holding a "=", so the requested syntax only reaches Select from parseFilter,
+	// it selects the same rows as the colon forms used on the command line.
+	for _, filter := range []string{"provider=gcp,name~=payments*", "provider:gcp,name~:payments*"} {
+		terms, err := parseFilter(filter)
+		require.NoError(t, err)
+
+		selected, err := Select(&Output{filter: terms}, accounts)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"payments-prod"}, accountNames(selected), filter)
+	}
+}
+
+func TestOutput_SortByDefault(t *testing.T) {
+	out := &Output{}
+	out.SortByDefault("level")
+
+	selected, err := Select(out, []account{{Name: "b", Level: 2}, {Name: "a", Level: 1}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, accountNames(selected))
+
+	out = &Output{sortBy: "name", descending: true}
+	out.SortByDefault("level")
+
+	selected, err = Select(out, []account{{Name: "a", Level: 2}, {Name: "b", Level: 1}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, accountNames(selected))
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+//nolint:gochecknoglobals //required key bindings for the help of the multi select list
+var (
+	toggleKey    = key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "select"))
+	toggleAllKey = key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "select all"))
+	confirmKey   = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm"))
+)
+
+// multiItemDelegate renders list items with a checkbox showing whether they are selected.
+type multiItemDelegate struct {
+	selected map[*Item]bool
+}
+
+// Height returns the height of the item (always 1).
+func (*multiItemDelegate) Height() int { return 1 }
+
+// Spacing returns the spacing between items (always 0).
+func (*multiItemDelegate) Spacing() int { return 0 }
+
+// Update returns the command to update the list. (always nil).
+func (*multiItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+// Render renders the list items with their selection state, highlighting the item under the cursor.
+//
+//nolint:gocritic //required for rendering list items and implementing ItemDelegate interface
+func (d *multiItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(*Item)
+	if !ok {
+		return
+	}
+
+	check := " "
+	if d.selected[i] {
+		check = "x"
+	}
+
+	str := fmt.Sprintf("%3d. [%s] %s", index+1, check, i.Name)
+
+	if index == m.Index() {
+		fmt.Fprint(w, selectedItemStyle.Render("> "+str))
+
+		return
+	}
+
+	fmt.Fprint(w, itemStyle.Render(str))
+}
+
+// multiModel represents the state of the multi select TUI interface.
+type multiModel struct {
+	delegate  *multiItemDelegate
+	confirmed bool       // confirmed indicates if the user confirmed the selection.
+	list      list.Model // list holds the list of items displayed in the TUI.
+}
+
+// Init initializes the model, returning nil for no commands.
+func (*multiModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles updates from messages, such as key presses or window resizing.
+// Space toggles the item under the cursor, a toggles all visible items and enter confirms the selection.
+func (m *multiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.list.SettingFilter() {
+			break
+		}
+
+		switch {
+		case msg.String() == "q" || msg.String() == "ctrl+c":
+			return m, tea.Quit
+
+		case key.Matches(msg, confirmKey):
+			m.confirmed = true
+			return m, tea.Quit
+
+		case key.Matches(msg, toggleKey):
+			if i, ok := m.list.SelectedItem().(*Item); ok {
+				m.delegate.selected[i] = !m.delegate.selected[i]
+			}
+
+			return m, nil
+
+		case key.Matches(msg, toggleAllKey):
+			m.toggleAll()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+
+	return m, cmd
+}
+
+// toggleAll selects all visible items, or clears them if all of them are already selected.
+func (m *multiModel) toggleAll() {
+	visible := m.list.VisibleItems()
+	all := true
+
+	for _, li := range visible {
+		if i, ok := li.(*Item); ok && !m.delegate.selected[i] {
+			all = false
+			break
+		}
+	}
+
+	for _, li := range visible {
+		if i, ok := li.(*Item); ok {
+			m.delegate.selected[i] = !all
+		}
+	}
+}
+
+// View renders the view of the current model, displaying the list to the user.
+func (m *multiModel) View() string {
+	return "\n" + m.list.View()
+}
+
+// RenderMultiList renders a list where the user can select any number of items.
+// It returns the selected items in their original order once the user confirms
+// the selection with enter, or nil if the user quits.
+func RenderMultiList(title string, items []*Item) ([]*Item, error) {
+	listItems := make([]list.Item, 0)
+
+	for i := range items {
+		listItems = append(listItems, items[i])
+	}
+
+	d := &multiItemDelegate{selected: make(map[*Item]bool)}
+
+	l := list.New(listItems, d, listWidth, listHeight)
+	l.Title = title
+	l.Styles.Title = titleStyle
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+	l.Styles.PaginationStyle = paginationStyle
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{toggleKey, toggleAllKey, confirmKey}
+	}
+
+	m := multiModel{delegate: d, list: l}
+
+	if _, er := tea.NewProgram(&m, tea.WithAltScreen()).Run(); er != nil {
+		return nil, er
+	}
+
+	if !m.confirmed {
+		return nil, nil
+	}
+
+	selected := make([]*Item, 0)
+
+	for _, i := range items {
+		if d.selected[i] {
+			selected = append(selected, i)
+		}
+	}
+
+	return selected, nil
+}
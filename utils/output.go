@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"gofr.dev/pkg/gofr"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats selected with -output.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputCSV   = "csv"
+
+	tablePadding = 2
+)
+
+var (
+	// ErrUnknownOutput is returned when the -output flag is not one of the supported formats.
+	ErrUnknownOutput = errors.New("unknown output format")
+
+	// ErrUnknownColumn is returned when -columns names a column the command does not have.
+	ErrUnknownColumn = errors.New("unknown column")
+)
+
+// Column is a column of a table or csv output, Value renders the column of a single row. The header is
+// upper-cased for tables and turned into a snake_case key for csv and -columns. Wide columns are only
+// shown with -wide, or when selected with -columns.
+type Column[T any] struct {
+	Header string
+	Value  func(row T) string
+	Wide   bool
+}
+
+func (c *Column[T]) key() string {
+	return columnKey(c.Header)
+}
+
+// Output is how a command renders its result, as selected with -output, -wide, -columns and -time-format.
+type Output struct {
+	Format     string
+	Wide       bool
+	Columns    []string
+	TimeFormat string
+
+	// Width is the width tables are fitted to, zero when the output is not a terminal.
+	Width int
+
+	filter     []filterTerm
+	sortBy     string
+	descending bool
+}
+
+// GetOutput returns the output selected with the -output, -wide, -columns and -time-format flags, and the rows
+// selected with -filter and -sort-by. Tables are fitted to the width of the terminal, or to COLUMNS when it is set.
+func GetOutput(ctx *gofr.Context) (*Output, error) {
+	format, err := OutputFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Output{Format: format, Width: terminalWidth()}
+
+	out.Wide, _ = strconv.ParseBool(ctx.Param("wide"))
+
+	if out.TimeFormat, err = TimeFormat(ctx, format); err != nil {
+		return nil, err
+	}
+
+	if out.filter, err = parseFilter(ctx.Param("filter")); err != nil {
+		return nil, err
+	}
+
+	out.sortBy = strings.TrimSpace(ctx.Param("sort-by"))
+	if strings.HasPrefix(out.sortBy, "-") {
+		out.sortBy, out.descending = out.sortBy[1:], true
+	}
+
+	for _, name := range strings.Split(ctx.Param("columns"), ",") {
+		if name = columnKey(name); name != "" {
+			out.Columns = append(out.Columns, name)
+		}
+	}
+
+	return out, nil
+}
+
+// OutputFormat returns the output format selected with -output, table when it is not set.
+func OutputFormat(ctx *gofr.Context) (string, error) {
+	switch format := strings.ToLower(ctx.Param("output")); format {
+	case "":
+		return OutputTable, nil
+	case OutputTable, OutputJSON, OutputYAML, OutputCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w %q, expected one of %s, %s, %s or %s",
+			ErrUnknownOutput, format, OutputTable, OutputJSON, OutputYAML, OutputCSV)
+	}
+}
+
+// Render renders the rows as selected by out. JSON and YAML render the rows themselves, using their json
+// tags, so that scripts get every field. Tables and csv render the selected columns.
+func Render[T any](out *Output, rows []T, columns []Column[T]) (string, error) {
+	switch out.Format {
+	case OutputJSON:
+		return marshalJSON(rows)
+	case OutputYAML:
+		return marshalYAML(rows)
+	}
+
+	columns, err := selectColumns(out, columns)
+	if err != nil {
+		return "", err
+	}
+
+	if out.Format == OutputCSV {
+		return renderCSV(rows, columns)
+	}
+
+	return renderTable(rows, columns, out.Width), nil
+}
+
+// RenderValue renders a single value as JSON or YAML, it is used by commands showing one resource.
+func RenderValue(format string, v any) (string, error) {
+	if format == OutputYAML {
+		return marshalYAML(v)
+	}
+
+	return marshalJSON(v)
+}
+
+// selectColumns returns the columns named with -columns in the given order, or else the columns shown
+// by default, with the wide columns for -wide.
+func selectColumns[T any](out *Output, columns []Column[T]) ([]Column[T], error) {
+	if len(out.Columns) == 0 {
+		selected := make([]Column[T], 0, len(columns))
+
+		for _, c := range columns {
+			if !c.Wide || out.Wide {
+				selected = append(selected, c)
+			}
+		}
+
+		return selected, nil
+	}
+
+	selected := make([]Column[T], 0, len(out.Columns))
+
+	for _, name := range out.Columns {
+		i := -1
+
+		for j := range columns {
+			if columns[j].key() == name {
+				i = j
+
+				break
+			}
+		}
+
+		if i < 0 {
+			keys := make([]string, len(columns))
+			for j := range columns {
+				keys[j] = columns[j].key()
+			}
+
+			return nil, fmt.Errorf("%w %q, available columns: %s", ErrUnknownColumn, name, strings.Join(keys, ", "))
+		}
+
+		selected = append(selected, columns[i])
+	}
+
+	return selected, nil
+}
+
+// columnKey turns a column header or a name given with -columns into the key of the column.
+func columnKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+}
+
+// IsTerminal reports whether the file, like os.Stdin or os.Stdout, is attached to a terminal.
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(f.Fd())
+}
+
+// Interactive reports whether the command renders a table to a terminal, the only output progress views and
+// spinners can be drawn over without corrupting it.
+func Interactive(ctx *gofr.Context) bool {
+	format, err := OutputFormat(ctx)
+
+	return err == nil && format == OutputTable && IsTerminal(os.Stdout)
+}
+
+// Messages returns where a command prints what is not part of its output, like warnings and prompts:
+// stdout when it renders a table to a terminal, stderr otherwise so that json, yaml and csv stay parseable.
+func Messages(ctx *gofr.Context) io.Writer {
+	if Interactive(ctx) {
+		return os.Stdout
+	}
+
+	return os.Stderr
+}
+
+// terminalWidth returns COLUMNS if it is set, the width of the terminal stdout is attached to otherwise,
+// and zero when stdout is not a terminal.
+func terminalWidth() int {
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil {
+		return width
+	}
+
+	return 0
+}
+
+func marshalJSON(v any) (string, error) {
+	b, err := json.MarshalIndent(nonNil(v), "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(b) + "\n", nil
+}
+
+// marshalYAML renders the value as YAML. The value is encoded as JSON first, so that the field names and
+// omitempty of the json tags apply, and the order of the fields is kept.
+func marshalYAML(v any) (string, error) {
+	b, err := json.Marshal(nonNil(v))
+	if err != nil {
+		return "", err
+	}
+
+	var node yaml.Node
+
+	if err = yaml.Unmarshal(b, &node); err != nil {
+		return "", err
+	}
+
+	blockStyle(&node)
+
+	var out bytes.Buffer
+
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(tablePadding)
+
+	if err = enc.Encode(&node); err != nil {
+		return "", err
+	}
+
+	return out.String(), enc.Close()
+}
+
+// blockStyle drops the flow style and quoting the node got from its JSON source.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, n := range node.Content {
+		blockStyle(n)
+	}
+}
+
+// nonNil turns nil slices into empty ones, so that they are rendered as [] instead of null.
+func nonNil(v any) any {
+	if b, err := json.Marshal(v); err == nil && string(b) == "null" {
+		return []any{}
+	}
+
+	return v
+}
+
+func renderCSV[T any](rows []T, columns []Column[T]) (string, error) {
+	var b strings.Builder
+
+	w := csv.NewWriter(&b)
+
+	record := make([]string, len(columns))
+	for i := range columns {
+		record[i] = columns[i].key()
+	}
+
+	if err := w.Write(record); err != nil {
+		return "", err
+	}
+
+	for _, row := range rows {
+		for i, c := range columns {
+			record[i] = c.Value(row)
+		}
+
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+
+	return b.String(), w.Error()
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+//nolint:gochecknoglobals //required TUI styles for displaying the progress
+var (
+	failedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#dc2626"))
+	doneStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#16a34a"))
+)
+
+// Progress shows one row per task with the step it is at or its result, while the tasks run concurrently.
+// Update and Done may be called from any goroutine. A nil Progress shows nothing.
+type Progress struct {
+	program *tea.Program
+	done    chan struct{}
+}
+
+// progressRow is the state of a single task in the progress view.
+type progressRow struct {
+	name   string
+	status string
+	done   bool
+	failed bool
+}
+
+// progressMsg updates the row of a task.
+type progressMsg struct {
+	row    int
+	status string
+	done   bool
+	failed bool
+}
+
+// progressModel represents the state of the progress TUI interface.
+type progressModel struct {
+	title   string
+	rows    []progressRow
+	spinner spinner.Model
+}
+
+// NewProgress returns the progress view of the named tasks, all of them waiting to start.
+func NewProgress(title string, names []string) *Progress {
+	rows := make([]progressRow, len(names))
+	for i, name := range names {
+		rows[i] = progressRow{name: name, status: "waiting"}
+	}
+
+	m := &progressModel{title: title, rows: rows, spinner: spinner.New(spinner.WithSpinner(spinner.Dot))}
+
+	return &Progress{
+		program: tea.NewProgram(m, tea.WithInput(nil)),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start renders the progress view until Stop is called.
+func (p *Progress) Start() {
+	if p == nil {
+		return
+	}
+
+	go func() {
+		defer close(p.done)
+
+		_, _ = p.program.Run()
+	}()
+}
+
+// Update sets the step the task of the row is at.
+func (p *Progress) Update(row int, step string) {
+	if p == nil {
+		return
+	}
+
+	p.program.Send(progressMsg{row: row, status: step})
+}
+
+// Done sets the result of the task of the row, marking it failed if err is not nil.
+func (p *Progress) Done(row int, result string, err error) {
+	if p == nil {
+		return
+	}
+
+	if err != nil {
+		result = fmt.Sprintf("%s: %v", result, err)
+	}
+
+	p.program.Send(progressMsg{row: row, status: result, done: true, failed: err != nil})
+}
+
+// Stop renders the final state of the tasks and stops the progress view.
+func (p *Progress) Stop() {
+	if p == nil {
+		return
+	}
+
+	p.program.Quit()
+
+	<-p.done
+}
+
+// Init starts the spinner of the running tasks.
+func (m *progressModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Update applies the progress of the tasks.
+func (m *progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case progressMsg:
+		if msg.row >= 0 && msg.row < len(m.rows) {
+			m.rows[msg.row].status = msg.status
+			m.rows[msg.row].done = msg.done
+			m.rows[msg.row].failed = msg.failed
+		}
+
+		return m, nil
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+
+		m.spinner, cmd = m.spinner.Update(msg)
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// View renders a row per task, with a spinner for the running ones.
+func (m *progressModel) View() string {
+	var (
+		b     strings.Builder
+		width int
+	)
+
+	for _, row := range m.rows {
+		width = max(width, len(row.name))
+	}
+
+	b.WriteString(titleStyle.Render(m.title) + "\n\n")
+
+	for _, row := range m.rows {
+		icon := m.spinner.View()
+
+		switch {
+		case row.failed:
+			icon = failedStyle.Render("✗")
+		case row.done:
+			icon = doneStyle.Render("✓")
+		}
+
+		fmt.Fprintf(&b, "%s %-*s  %s\n", icon, width, row.name, row.status)
+	}
+
+	return b.String()
+}
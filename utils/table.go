@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// minColumnWidth is the width columns are never truncated below, even if the table overflows the terminal.
+	minColumnWidth = 6
+	ellipsis       = "…"
+)
+
+// renderTable renders the rows as a table with a header, with the columns aligned by display width so that
+// multi-byte and wide characters line up. With a width the widest columns are truncated until the table fits.
+func renderTable[T any](rows []T, columns []Column[T], width int) string {
+	cells := make([][]string, 0, len(rows)+1)
+
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = strings.ToUpper(c.Header)
+	}
+
+	cells = append(cells, header)
+
+	for _, row := range rows {
+		line := make([]string, len(columns))
+		for i, c := range columns {
+			line[i] = c.Value(row)
+		}
+
+		cells = append(cells, line)
+	}
+
+	widths := fitWidths(columnWidths(cells, len(columns)), width)
+
+	var b strings.Builder
+
+	for _, line := range cells {
+		for i, cell := range line {
+			if ansi.StringWidth(cell) > widths[i] {
+				cell = ansi.Truncate(cell, widths[i], ellipsis)
+			}
+
+			b.WriteString(cell)
+
+			if i < len(line)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-ansi.StringWidth(cell)+tablePadding))
+			}
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// columnWidths returns the display width of the widest cell of every column.
+func columnWidths(cells [][]string, n int) []int {
+	widths := make([]int, n)
+
+	for _, line := range cells {
+		for i, cell := range line {
+			widths[i] = max(widths[i], ansi.StringWidth(cell))
+		}
+	}
+
+	return widths
+}
+
+// fitWidths shrinks the widest columns until the table fits into the width, no column is shrunk
+// below minColumnWidth. A width of zero leaves the columns as they are.
+func fitWidths(widths []int, width int) []int {
+	if width <= 0 {
+		return widths
+	}
+
+	total := tablePadding * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+
+	for total > width {
+		widest := 0
+
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+
+		widths[widest]--
+		total--
+	}
+
+	return widths
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+)
+
+type tableRow struct {
+	name, region string
+}
+
+func tableColumns() []Column[tableRow] {
+	return []Column[tableRow]{
+		{Header: "Name", Value: func(r tableRow) string { return r.name }},
+		{Header: "Region", Value: func(r tableRow) string { return r.region }},
+	}
+}
+
+func Test_renderTable(t *testing.T) {
+	rows := []tableRow{{"支付服务", "us-central1"}, {"search", "europe-west1"}}
+
+	testCases := []struct {
+		name     string
+		width    int
+		expected string
+	}{
+		{
+			name:  "aligned by display width",
+			width: 0,
+			expected: "NAME      REGION\n" +
+				"支付服务  us-central1\n" +
+				"search    europe-west1\n",
+		},
+		{
+			name:  "widest column truncated to fit",
+			width: 18,
+			expected: "NAME      REGION\n" +
+				"支付服务  us-cent…\n" +
+				"search    europe-…\n",
+		},
+		{
+			name:  "truncated by display width",
+			width: 15,
+			expected: "NAME    REGION\n" +
+				"支付…   us-cen…\n" +
+				"search  europe…\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, renderTable(rows, tableColumns(), tc.width))
+		})
+	}
+}
+
+func Test_fitWidths(t *testing.T) {
+	assert.Equal(t, []int{10, 8}, fitWidths([]int{10, 8}, 0))
+	assert.Equal(t, []int{8, 8}, fitWidths([]int{10, 8}, 18))
+	assert.Equal(t, []int{6, 6}, fitWidths([]int{10, 8}, 5))
+}
+
+func TestGetOutput_width(t *testing.T) {
+	t.Setenv("COLUMNS", "40")
+
+	out, err := GetOutput(&gofr.Context{Request: cmd.NewRequest([]string{""})})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 40, out.Width)
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Time formats selected with -time-format.
+const (
+	TimeLocal    = "local"
+	TimeRelative = "relative"
+	TimeRFC3339  = "rfc3339"
+
+	localLayout = "2006-01-02 15:04:05 MST"
+	hoursPerDay = 24
+)
+
+// ErrUnknownTimeFormat is returned when the -time-format flag is not one of the supported formats.
+var ErrUnknownTimeFormat = errors.New("unknown time format")
+
+// Timestamp is a time sent by zop api. RFC 3339 timestamps and timestamps like 2006-01-02 15:04:05 are parsed,
+// others are kept as they are. Timestamps are encoded as RFC 3339, so that scripts can parse them.
+type Timestamp struct {
+	time.Time
+
+	// raw is the value of timestamps that could not be parsed.
+	raw string
+}
+
+// ParseTimestamp parses a timestamp of zop api.
+func ParseTimestamp(value string) Timestamp {
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Timestamp{Time: t}
+		}
+	}
+
+	return Timestamp{raw: value}
+}
+
+// UnmarshalJSON parses the timestamp from a JSON string, null is the zero timestamp.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	var value *string
+
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+
+	if value == nil {
+		*t = Timestamp{}
+
+		return nil
+	}
+
+	*t = ParseTimestamp(*value)
+
+	return nil
+}
+
+// MarshalJSON encodes the timestamp as an RFC 3339 string.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// String returns the timestamp in RFC 3339, the raw value for timestamps that could not be parsed and
+// an empty string for the zero timestamp.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return t.raw
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// TimeFormat returns the time format selected with -time-format. Tables default to the local time zone,
+// CSV to RFC 3339.
+func TimeFormat(ctx *gofr.Context, format string) (string, error) {
+	switch timeFormat := strings.ToLower(ctx.Param("time-format")); timeFormat {
+	case "":
+		if format == OutputCSV {
+			return TimeRFC3339, nil
+		}
+
+		return TimeLocal, nil
+	case TimeLocal, TimeRelative, TimeRFC3339:
+		return timeFormat, nil
+	default:
+		return "", fmt.Errorf("%w %q, expected one of %s, %s or %s",
+			ErrUnknownTimeFormat, timeFormat, TimeLocal, TimeRelative, TimeRFC3339)
+	}
+}
+
+// FormatTime formats the timestamp in the time format of the output.
+func (o *Output) FormatTime(t Timestamp) string {
+	if t.IsZero() {
+		return t.raw
+	}
+
+	switch o.TimeFormat {
+	case TimeRFC3339:
+		return t.String()
+	case TimeRelative:
+		return relativeTime(t.Time, time.Now())
+	default:
+		return t.Local().Format(localLayout)
+	}
+}
+
+// relativeTime returns the age of t at now, like 3h ago, in the largest whole unit.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+
+	suffix := " ago"
+	if d < 0 {
+		d, suffix = -d, " from now"
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%s", int(d.Minutes()), suffix)
+	case d < hoursPerDay*time.Hour:
+		return fmt.Sprintf("%dh%s", int(d.Hours()), suffix)
+	default:
+		return fmt.Sprintf("%dd%s", int(d.Hours()/hoursPerDay), suffix)
+	}
+}
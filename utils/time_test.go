@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamp_JSON(t *testing.T) {
+	var values struct {
+		RFC3339  Timestamp `json:"rfc3339"`
+		DateTime Timestamp `json:"dateTime"`
+		Unknown  Timestamp `json:"unknown"`
+		Null     Timestamp `json:"null"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"rfc3339": "2024-03-04T10:00:00+02:00",
+		"dateTime": "2024-03-04 10:00:00", "unknown": "yesterday", "null": null}`), &values))
+
+	assert.True(t, values.RFC3339.Equal(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, values.DateTime.Equal(time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)))
+	assert.True(t, values.Unknown.IsZero())
+	assert.True(t, values.Null.IsZero())
+
+	b, err := json.Marshal(values)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rfc3339": "2024-03-04T10:00:00+02:00", "dateTime": "2024-03-04T10:00:00Z",
+		"unknown": "yesterday", "null": ""}`, string(b))
+}
+
+func TestOutput_FormatTime(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+
+	t.Cleanup(func() { time.Local = local })
+
+	ts := ParseTimestamp("2024-03-04T10:00:00+02:00")
+
+	assert.Equal(t, "2024-03-04 08:00:00 UTC", (&Output{TimeFormat: TimeLocal}).FormatTime(ts))
+	assert.Equal(t, "2024-03-04T10:00:00+02:00", (&Output{TimeFormat: TimeRFC3339}).FormatTime(ts))
+	assert.Equal(t, "yesterday", (&Output{TimeFormat: TimeRelative}).FormatTime(ParseTimestamp("yesterday")))
+}
+
+func Test_relativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		t        time.Time
+		expected string
+	}{
+		{t: now.Add(-30 * time.Second), expected: "just now"},
+		{t: now.Add(-5 * time.Minute), expected: "5m ago"},
+		{t: now.Add(-3*time.Hour - 59*time.Minute), expected: "3h ago"},
+		{t: now.Add(-50 * time.Hour), expected: "2d ago"},
+		{t: now.Add(2 * time.Hour), expected: "2h from now"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, relativeTime(tc.t, now))
+	}
+}
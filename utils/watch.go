@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gofr.dev/pkg/gofr"
+)
+
+const (
+	defaultWatchInterval = 5 * time.Second
+	minWatchInterval     = time.Second
+)
+
+// ErrInvalidInterval is returned when -interval is not a duration of at least a second.
+var ErrInvalidInterval = errors.New("invalid interval, expected a duration of at least 1s, like 10s or 1m")
+
+//nolint:gochecknoglobals //required TUI styles for displaying the watched output
+var (
+	changedStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#06b6d4"))
+	watchHeaderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#6b7280"))
+)
+
+// watchTickMsg asks the watch model to render the output again.
+type watchTickMsg struct{}
+
+// watchResultMsg is the output of a single render.
+type watchResultMsg struct {
+	output string
+	err    error
+}
+
+// watchModel renders the output every interval, highlighting the lines that changed since the previous render.
+// Errors are shown below the last output, so that a failing request does not end the watch.
+type watchModel struct {
+	render   func() (any, error)
+	interval time.Duration
+	now      func() time.Time
+
+	lines    []string
+	changed  []bool
+	err      error
+	updated  time.Time
+	quitting bool
+}
+
+// Watch renders the output of render once, or with -watch, again every -interval until q is pressed.
+// The first render runs before the watch starts, so that its errors are returned like without -watch.
+func Watch(ctx *gofr.Context, render func() (any, error)) (any, error) {
+	interval, watch, err := getWatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := render()
+	if err != nil || !watch {
+		return output, err
+	}
+
+	m := &watchModel{render: render, interval: interval, now: time.Now}
+	m.update(fmt.Sprint(output), nil)
+
+	if _, err = tea.NewProgram(m).Run(); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// getWatch returns the interval selected with -interval and whether -watch is set.
+func getWatch(ctx *gofr.Context) (time.Duration, bool, error) {
+	watch, _ := strconv.ParseBool(ctx.Param("watch"))
+
+	param := ctx.Param("interval")
+	if param == "" {
+		return defaultWatchInterval, watch, nil
+	}
+
+	interval, err := time.ParseDuration(param)
+	if err != nil {
+		// A plain number is a number of seconds.
+		seconds, convErr := strconv.Atoi(param)
+		if convErr != nil {
+			return 0, false, fmt.Errorf("%w: %q", ErrInvalidInterval, param)
+		}
+
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	if interval < minWatchInterval {
+		return 0, false, fmt.Errorf("%w: %q", ErrInvalidInterval, param)
+	}
+
+	return interval, watch, nil
+}
+
+// Init waits for the first interval, the model starts with the output of the first render.
+func (m *watchModel) Init() tea.Cmd {
+	return m.tick()
+}
+
+// Update renders the output again on every tick and quits on q or ctrl+c.
+func (m *watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			m.quitting = true
+
+			return m, tea.Quit
+		}
+	case watchTickMsg:
+		return m, func() tea.Msg {
+			output, err := m.render()
+
+			return watchResultMsg{output: fmt.Sprint(output), err: err}
+		}
+	case watchResultMsg:
+		m.update(msg.output, msg.err)
+
+		return m, m.tick()
+	}
+
+	return m, nil
+}
+
+func (m *watchModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return watchTickMsg{} })
+}
+
+// update sets the output of a render. Lines that were not part of the previous output are marked changed,
+// nothing is marked on the first render. The previous output is kept when the render failed.
+func (m *watchModel) update(output string, err error) {
+	m.err = err
+	if err != nil {
+		return
+	}
+
+	previous := make(map[string]bool, len(m.lines))
+	for _, line := range m.lines {
+		previous[line] = true
+	}
+
+	first := m.updated.IsZero()
+
+	m.lines = strings.Split(strings.TrimRight(output, "\n"), "\n")
+	m.changed = make([]bool, len(m.lines))
+
+	for i, line := range m.lines {
+		m.changed[i] = !first && !previous[line]
+	}
+
+	m.updated = m.now()
+}
+
+// View renders the last output below a line with the interval and the time of the last render.
+func (m *watchModel) View() string {
+	var b strings.Builder
+
+	header := fmt.Sprintf("Every %s, updated at %s", m.interval, m.updated.Format(time.TimeOnly))
+	if !m.quitting {
+		header += ", press q to quit"
+	}
+
+	b.WriteString(watchHeaderStyle.Render(header) + "\n\n")
+
+	for i, line := range m.lines {
+		if m.changed[i] {
+			line = changedStyle.Render(line)
+		}
+
+		b.WriteString(line + "\n")
+	}
+
+	if m.err != nil {
+		b.WriteString("\n" + failedStyle.Render(fmt.Sprintf("refresh failed: %v", m.err)) + "\n")
+	}
+
+	return b.String()
+}
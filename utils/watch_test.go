@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/cmd"
+)
+
+var errRefresh = errors.New("connection refused")
+
+func Test_getWatch(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		interval time.Duration
+		watch    bool
+		err      error
+	}{
+		{args: []string{""}, interval: defaultWatchInterval},
+		{args: []string{"", "-watch"}, interval: defaultWatchInterval, watch: true},
+		{args: []string{"", "-watch", "-interval=1m"}, interval: time.Minute, watch: true},
+		{args: []string{"", "-watch", "-interval=10"}, interval: 10 * time.Second, watch: true},
+		{args: []string{"", "-watch", "-interval=100ms"}, err: ErrInvalidInterval},
+		{args: []string{"", "-watch", "-interval=often"}, err: ErrInvalidInterval},
+	}
+
+	for _, tc := range testCases {
+		interval, watch, err := getWatch(&gofr.Context{Request: cmd.NewRequest(tc.args)})
+
+		require.ErrorIs(t, err, tc.err)
+		assert.Equal(t, tc.interval, interval)
+		assert.Equal(t, tc.watch, watch)
+	}
+}
+
+func TestWatch_once(t *testing.T) {
+	resp, err := Watch(&gofr.Context{Request: cmd.NewRequest([]string{""})}, func() (any, error) { return "table\n", nil })
+
+	require.NoError(t, err)
+	assert.Equal(t, "table\n", resp)
+
+	resp, err = Watch(&gofr.Context{Request: cmd.NewRequest([]string{"", "-watch"})},
+		func() (any, error) { return "", errRefresh })
+
+	require.ErrorIs(t, err, errRefresh)
+	assert.Equal(t, "", resp)
+}
+
+func Test_watchModel(t *testing.T) {
+	outputs := []string{"NAME  STATUS\npayments  validated\n"}
+	errs := []error{nil}
+
+	m := &watchModel{
+		render: func() (any, error) {
+			output, err := outputs[0], errs[0]
+			outputs, errs = outputs[1:], errs[1:]
+
+			return output, err
+		},
+		interval: time.Second,
+		now:      func() time.Time { return time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC) },
+	}
+
+	m.update("NAME  STATUS\npayments  pending\n", nil)
+
+	assert.Equal(t, []bool{false, false}, m.changed, "nothing is changed on the first render")
+
+	_, refresh := m.Update(watchTickMsg{})
+	_, next := m.Update(refresh())
+
+	require.NotNil(t, next)
+	assert.Equal(t, []string{"NAME  STATUS", "payments  validated"}, m.lines)
+	assert.Equal(t, []bool{false, true}, m.changed)
+
+	m.update("", errRefresh)
+
+	assert.Equal(t, []string{"NAME  STATUS", "payments  validated"}, m.lines, "the last output is kept on errors")
+	assert.Contains(t, m.View(), "refresh failed: connection refused")
+	assert.Contains(t, m.View(), "Every 1s, updated at 10:00:00, press q to quit")
+
+	_, quit := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+
+	assert.Equal(t, tea.Quit(), quit())
+	assert.NotContains(t, m.View(), "press q to quit")
+}